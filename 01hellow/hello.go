@@ -1,25 +1,134 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"os"
 
 	"example.com/greetings"
 )
 
+// exit codes of the hello command
+const (
+	exitOK      = 0 // every name was greeted
+	exitGreet   = 1 // at least one name could not be greeted
+	exitUsage   = 2 // bad flags or arguments
+	exitIOError = 3 // names could not be read or greetings written
+)
+
 func main() {
-	//setting the std Logger:
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run is the whole hello command, parameterized over its arguments and
+// standard streams so it can be tested; it returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	//setting up a Logger on stderr:
 	//prefix for every log call
 	//and using flag 0(---enum like thing to where 0 means no flags) to disable logging time and date of the call
-	log.SetPrefix("greetings: ")
-	log.SetFlags(0)
+	logger := log.New(stderr, "greetings: ", 0)
+
+	fs := flag.NewFlagSet("hello", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: hello [flags] [name ...]\n\n")
+		fmt.Fprintf(stderr, "Greets each name given as an argument, or one name per line\n")
+		fmt.Fprintf(stderr, "from -file or standard input when there are no arguments.\n\n")
+		fs.PrintDefaults()
+	}
+
+	file := fs.String("file", "", "read names from `path`, one per line (- for stdin)")
+	format := fs.String("format", "plain", "output format: plain, json or csv")
+	locale := fs.String("locale", greetings.DefaultLocale, "BCP 47 `tag` of the greeting catalog")
+	seed := fs.Int64("seed", 0, "seed for picking greetings; 0 picks a different one each run")
+	strict := fs.Bool("strict", false, "stop at the first name that cannot be greeted (default)")
+	lenient := fs.Bool("lenient", false, "report names that cannot be greeted and carry on")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *strict && *lenient {
+		logger.Print("-strict and -lenient are mutually exclusive")
+		return exitUsage
+	}
+	if *file != "" && fs.NArg() > 0 {
+		logger.Print("names can come from -file or arguments, not both")
+		return exitUsage
+	}
+
+	//buffered so that big name lists are not written one syscall per line
+	bw := bufio.NewWriter(stdout)
+	out, err := newWriter(*format, bw)
+	if err != nil {
+		logger.Print(err)
+		return exitUsage
+	}
 
-	names := []string{"naveen", "coolio", "Darren Brown"}
+	g := &greetings.Greeter{}
+	if *seed != 0 {
+		g.Selector = greetings.NewRandomSelector(rand.NewSource(*seed))
+	}
+
+	code := exitOK
+	greet := func(gr greetings.Greeting, err error) error {
+		if err != nil {
+			logger.Print(err)
+			code = exitGreet
+			if *lenient {
+				return nil
+			}
+			return errStop
+		}
+		return out.Write(gr)
+	}
+
+	if fs.NArg() > 0 {
+		err = g.EachIn(*locale, fs.Args(), greet)
+	} else {
+		err = streamNames(*file, stdin, func(r io.Reader) error {
+			return g.StreamIn(*locale, r, greet)
+		})
+	}
+	if err != nil && err != errStop {
+		// still finish the output, so what was greeted is not lost
+		logger.Print(err)
+		code = exitIOError
+	}
+
+	if err := out.Close(); err != nil {
+		logger.Print(err)
+		return exitIOError
+	}
+	if err := bw.Flush(); err != nil {
+		logger.Print(err)
+		return exitIOError
+	}
+
+	return code
+}
+
+// errStop ends the stream of names at the first bad one in -strict mode.
+var errStop = errors.New("stop")
+
+// streamNames calls fn with the file at path, or stdin if path is "" or "-".
+func streamNames(path string, stdin io.Reader, fn func(io.Reader) error) error {
+	if path == "" || path == "-" {
+		return fn(stdin)
+	}
 
-	messages, err := greetings.Hellos(names)
+	f, err := os.Open(path)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+	defer f.Close()
 
-	fmt.Println(messages)
+	return fn(f)
 }
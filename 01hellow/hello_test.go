@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestRunGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stderr string
+	}{
+		{"args", []string{"-seed", "1", "naveen", "coolio", "Darren Brown"}, "", exitOK, ""},
+		{"json", []string{"-seed", "1", "-format", "json", "naveen", "coolio"}, "", exitOK, ""},
+		{"csv", []string{"-seed", "1", "-format", "csv", "naveen", "Brown, Darren"}, "", exitOK, ""},
+		{"stdin", []string{"-seed", "1"}, "naveen\r\ncoolio\n", exitOK, ""},
+		{"file", []string{"-seed", "1", "-file", "testdata/names.txt"}, "", exitOK, ""},
+		{"strict", []string{"-seed", "1", "naveen", "", "coolio"}, "", exitGreet, `name 1 (""): empty name`},
+		{"lenient", []string{"-seed", "1", "-lenient", "naveen", "", "coolio"}, "", exitGreet, `name 1 (""): empty name`},
+		{"unknown_locale", []string{"-locale", "xx", "naveen"}, "", exitGreet, `unknown locale "xx"`},
+		{"bad_format", []string{"-format", "xml", "naveen"}, "", exitUsage, `unknown output format "xml"`},
+		{"strict_lenient", []string{"-strict", "-lenient", "naveen"}, "", exitUsage, "mutually exclusive"},
+		{"missing_file", []string{"-file", "testdata/nonexistent.txt"}, "", exitIOError, "nonexistent.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			if code != tt.code {
+				t.Fatalf("run(%q) = %d, want %d; stderr:\n%s", tt.args, code, tt.code, &stderr)
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Fatalf("run(%q) stderr = %q, want it to contain %q", tt.args, &stderr, tt.stderr)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, stdout.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := stdout.String(); got != string(want) {
+				t.Fatalf("run(%q) stdout =\n%s\nwant (%s):\n%s", tt.args, got, golden, want)
+			}
+		})
+	}
+}
+
+func TestRunReadError(t *testing.T) {
+	stdin := io.MultiReader(strings.NewReader("naveen\ncoolio\n"), iotest.ErrReader(errors.New("disk on fire")))
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-seed", "1", "-format", "json"}, stdin, &stdout, &stderr)
+
+	if code != exitIOError || !strings.Contains(stderr.String(), "disk on fire") {
+		t.Fatalf("run = %d, stderr %q, want %d and the read error", code, &stderr, exitIOError)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "json.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stdout.String(); got != string(want) {
+		t.Fatalf("run stdout =\n%s\nwant the names read before the error:\n%s", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"example.com/greetings"
+)
+
+// greetingWriter writes greetings in one of the -format output formats.
+type greetingWriter interface {
+	Write(g greetings.Greeting) error
+	Close() error
+}
+
+func newWriter(format string, w io.Writer) (greetingWriter, error) {
+	switch format {
+	case "plain":
+		return &plainWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// plainWriter writes one greeting per line.
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p *plainWriter) Write(g greetings.Greeting) error {
+	_, err := fmt.Fprintln(p.w, g.Message)
+	return err
+}
+
+func (p *plainWriter) Close() error {
+	return nil
+}
+
+// jsonWriter writes a JSON array of greetings, one element per line.
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(g greetings.Greeting) error {
+	b, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if j.count == 0 {
+		sep = "[\n"
+	}
+	j.count++
+
+	_, err = fmt.Fprintf(j.w, "%s  %s", sep, b)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	if j.count == 0 {
+		_, err := fmt.Fprintln(j.w, "[]")
+		return err
+	}
+	_, err := fmt.Fprint(j.w, "\n]\n")
+	return err
+}
+
+// csvWriter writes a name,message header and one record per greeting.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvWriter) Write(g greetings.Greeting) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.w.Write([]string{g.Name, g.Message})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	return c.w.Write([]string{"name", "message"})
+}
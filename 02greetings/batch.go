@@ -0,0 +1,41 @@
+package greetings
+
+import "fmt"
+
+// IndexError records the failure to greet the name at Index of a batch.
+type IndexError struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("name %d (%q): %v", e.Index, e.Name, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// HellosPartial is like Hellos but keeps going past bad names.
+func HellosPartial(names []string) (map[string]string, error) {
+	return defaultGreeter.HellosPartial(names)
+}
+
+// HellosPartialIn is like HellosIn but keeps going past bad names.
+func HellosPartialIn(locale string, names []string) (map[string]string, error) {
+	return defaultGreeter.HellosPartialIn(locale, names)
+}
+
+// HellosPartial is like Hellos but keeps going past bad names.
+func (g *Greeter) HellosPartial(names []string) (map[string]string, error) {
+	return g.HellosPartialIn(DefaultLocale, names)
+}
+
+// HellosPartialIn greets every name it can using the catalog of locale.
+// It returns the successful greetings together with an errors.Join of
+// one *IndexError per name that failed, or a nil error if none did.
+func (g *Greeter) HellosPartialIn(locale string, names []string) (map[string]string, error) {
+	gs, err := g.greetAll(locale, names, true)
+	return gs.Map(), err
+}
@@ -0,0 +1,34 @@
+package greetings
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHellosPartial(t *testing.T) {
+	names := []string{"Gladys", "", "Samantha", ""}
+
+	messages, err := HellosPartial(names)
+	if len(messages) != 2 || messages["Gladys"] == "" || messages["Samantha"] == "" {
+		t.Fatalf("HellosPartial(%q) messages = %q, want greetings for Gladys and Samantha", names, messages)
+	}
+
+	var indexes []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ie *IndexError
+		if !errors.As(e, &ie) {
+			t.Fatalf("HellosPartial(%q) error %v is not an *IndexError", names, e)
+		}
+		indexes = append(indexes, ie.Index)
+	}
+	if len(indexes) != 2 || indexes[0] != 1 || indexes[1] != 3 {
+		t.Fatalf("HellosPartial(%q) failed indexes = %v, want [1 3]", names, indexes)
+	}
+}
+
+func TestHellosPartialNoErrors(t *testing.T) {
+	messages, err := HellosPartial([]string{"Gladys"})
+	if len(messages) != 1 || err != nil {
+		t.Fatalf(`HellosPartial(["Gladys"]) = %q, %v, want 1 greeting, nil`, messages, err)
+	}
+}
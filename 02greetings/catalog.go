@@ -0,0 +1,232 @@
+package greetings
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// DefaultLocale is the locale used by Hello and Hellos.
+const DefaultLocale = "en"
+
+// UnknownLocaleError is returned when no catalog matches a locale
+// or any of its fallbacks.
+type UnknownLocaleError struct {
+	Locale string
+}
+
+func (e *UnknownLocaleError) Error() string {
+	return fmt.Sprintf("unknown locale %q", e.Locale)
+}
+
+// InvalidLocaleError is returned when a locale is not a well-formed BCP 47 tag.
+type InvalidLocaleError struct {
+	Locale string
+}
+
+func (e *InvalidLocaleError) Error() string {
+	return fmt.Sprintf("invalid locale %q", e.Locale)
+}
+
+// catalogs holds the greeting formats keyed by canonical BCP 47 tag.
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string][]format{
+		"en": {
+			sprintfFormat("Hi, %v, Welcome!"),
+			sprintfFormat("Great to see you, %v!"),
+			sprintfFormat("Hail, %v! Well met!"),
+		},
+	}
+)
+
+// RegisterCatalog adds the greeting formats for locale, replacing any
+// formats already registered for the same tag. Every format must
+// contain exactly one %v verb for the name.
+func RegisterCatalog(locale string, formats []string) error {
+	tag, fs, err := parseCatalog(locale, formats)
+	if err != nil {
+		return err
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[tag] = fs
+
+	return nil
+}
+
+// parseCatalog checks the formats for locale as RegisterCatalog does
+// and returns them with the canonical tag, without registering them.
+func parseCatalog(locale string, formats []string) (string, []format, error) {
+	tag, err := canonicalLocale(locale)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(formats) == 0 {
+		return "", nil, fmt.Errorf("catalog %q has no formats", tag)
+	}
+	fs := make([]format, len(formats))
+	for i, f := range formats {
+		if strings.Count(f, "%v") != 1 {
+			return "", nil, fmt.Errorf("catalog %q: format %q must contain exactly one %%v", tag, f)
+		}
+		fs[i] = sprintfFormat(f)
+	}
+	return tag, fs, nil
+}
+
+// RegisterTemplates parses each text as a text/template and adds it to
+// the catalog of locale, alongside the formats already registered there.
+// The templates are executed with a Recipient, e.g.
+//
+//	Good {{timeOfDay .Time}}, {{with .Title}}{{.}} {{end}}{{.Name}}!
+func RegisterTemplates(locale string, texts ...string) error {
+	tag, err := canonicalLocale(locale)
+	if err != nil {
+		return err
+	}
+	if len(texts) == 0 {
+		return fmt.Errorf("catalog %q has no templates", tag)
+	}
+
+	fs := make([]format, len(texts))
+	for i, text := range texts {
+		tmpl, err := template.New(tag).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("catalog %q: %w", tag, err)
+		}
+		fs[i] = templateFormat{tmpl}
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[tag] = append(slices.Clip(catalogs[tag]), fs...)
+
+	return nil
+}
+
+// LoadCatalogs reads a JSON bundle mapping BCP 47 tags to lists of
+// greeting formats and registers each of them, e.g.
+//
+//	{"pt": ["Olá, %v!"], "pt-BR": ["Oi, %v! Tudo bem?"]}
+//
+// Either the whole bundle is registered or, if any catalog in it is
+// invalid, none of it is.
+func LoadCatalogs(r io.Reader) error {
+	var bundle map[string][]string
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return fmt.Errorf("decoding catalog bundle: %w", err)
+	}
+
+	// sorted, so the same bundle always fails with the same error
+	locales := make([]string, 0, len(bundle))
+	for locale := range bundle {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+
+	parsed := make(map[string][]format, len(bundle))
+	for _, locale := range locales {
+		tag, fs, err := parseCatalog(locale, bundle[locale])
+		if err != nil {
+			return err
+		}
+		parsed[tag] = fs
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	for tag, fs := range parsed {
+		catalogs[tag] = fs
+	}
+
+	return nil
+}
+
+// lookupFormats returns the formats for the first tag in the fallback
+// chain of locale that has a non-empty catalog, e.g. "pt-BR" -> "pt".
+func lookupFormats(locale string) ([]format, error) {
+	tag, err := canonicalLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	for _, t := range fallbackChain(tag) {
+		if formats := catalogs[t]; len(formats) > 0 {
+			return formats, nil
+		}
+	}
+
+	return nil, &UnknownLocaleError{Locale: locale}
+}
+
+// fallbackChain returns tag followed by its parents, dropping one
+// subtag at a time: "zh-Hant-TW" -> "zh-Hant" -> "zh".
+func fallbackChain(tag string) []string {
+	chain := []string{tag}
+	for i := strings.LastIndexByte(tag, '-'); i > 0; i = strings.LastIndexByte(tag, '-') {
+		tag = tag[:i]
+		chain = append(chain, tag)
+	}
+	return chain
+}
+
+// canonicalLocale checks that locale is a well-formed BCP 47 tag and
+// returns it with the conventional casing ("PT_br" becomes "pt-BR").
+func canonicalLocale(locale string) (string, error) {
+	subtags := strings.FieldsFunc(locale, func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 || len(subtags) != strings.Count(locale, "-")+strings.Count(locale, "_")+1 {
+		return "", &InvalidLocaleError{Locale: locale}
+	}
+
+	for i, s := range subtags {
+		if len(s) > 8 || !isAlnum(s) {
+			return "", &InvalidLocaleError{Locale: locale}
+		}
+
+		switch {
+		case i == 0:
+			// primary language subtag: 2-8 letters, lower case
+			if len(s) < 2 || !isAlpha(s) {
+				return "", &InvalidLocaleError{Locale: locale}
+			}
+			subtags[i] = strings.ToLower(s)
+		case len(s) == 4 && isAlpha(s):
+			// script subtag, title case
+			subtags[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+		case len(s) == 2 && isAlpha(s), len(s) == 3 && !isAlpha(s):
+			// region subtag, upper case
+			subtags[i] = strings.ToUpper(s)
+		default:
+			subtags[i] = strings.ToLower(s)
+		}
+	}
+
+	return strings.Join(subtags, "-"), nil
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package greetings
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// HellosConcurrent is like HellosOrdered but greets names on up to
+// workers goroutines.
+func HellosConcurrent(ctx context.Context, names []string, workers int) (Greetings, error) {
+	return defaultGreeter.HellosConcurrent(ctx, names, workers)
+}
+
+// HellosConcurrent is like HellosOrdered but greets names on up to
+// workers goroutines.
+func (g *Greeter) HellosConcurrent(ctx context.Context, names []string, workers int) (Greetings, error) {
+	return g.HellosConcurrentIn(ctx, DefaultLocale, names, workers)
+}
+
+// HellosConcurrentIn greets names using the catalog of locale on a pool
+// of workers goroutines (GOMAXPROCS when workers <= 0) and returns the
+// greetings in input order.
+//
+// A name that fails stops the batch, and the failing name with the
+// lowest index is returned as an *IndexError, the same one HellosOrdered
+// would report. If ctx is done before every name is greeted, its error
+// is returned instead.
+func (g *Greeter) HellosConcurrentIn(ctx context.Context, locale string, names []string, workers int) (Greetings, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(names))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	gs := make(Greetings, len(names))
+	jobs := make(chan int)
+
+	// a failure cancels the names not yet handed out. They are handed
+	// out in order, so the names before it are all in flight and still
+	// run, in case one of them fails too and has a lower index.
+	var (
+		mu      sync.Mutex
+		failErr *IndexError
+	)
+	fail := func(err *IndexError) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failErr == nil || err.Index < failErr.Index {
+			failErr = err
+		}
+		cancel()
+	}
+	skip := func(i int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if failErr != nil {
+			return i > failErr.Index
+		}
+		return ctx.Err() != nil
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// each worker receives indexes until jobs is closed,
+			// so every result lands in its own slot of gs
+			for i := range jobs {
+				if skip(i) {
+					continue
+				}
+
+				key, message, err := g.greet(Recipient{Name: names[i], Locale: locale})
+				if err != nil {
+					fail(&IndexError{Index: i, Name: names[i], Err: err})
+					continue
+				}
+				gs[i] = Greeting{Name: key, Message: message, Index: i}
+			}
+		}()
+	}
+
+feed:
+	for i := range names {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failErr != nil {
+		return nil, failErr
+	}
+	// only the parent can have canceled ctx at this point
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return gs, nil
+}
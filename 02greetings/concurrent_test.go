@@ -0,0 +1,76 @@
+package greetings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestHellosConcurrentOrder(t *testing.T) {
+	names := make([]string, 1000)
+	for i := range names {
+		names[i] = fmt.Sprintf("name%d", i)
+	}
+
+	g := NewGreeter(HashSelector{})
+	gs, err := g.HellosConcurrent(context.Background(), names, 8)
+	if err != nil || len(gs) != len(names) {
+		t.Fatalf("HellosConcurrent = %d greetings, %v, want %d, nil", len(gs), err, len(names))
+	}
+
+	want, _ := g.HellosOrdered(names)
+	for i := range want {
+		if gs[i] != want[i] {
+			t.Fatalf("HellosConcurrent[%d] = %+v, want %+v", i, gs[i], want[i])
+		}
+	}
+}
+
+func TestHellosConcurrentError(t *testing.T) {
+	names := []string{"Gladys", "Samantha", "", "Darrin"}
+
+	gs, err := HellosConcurrent(context.Background(), names, 2)
+	var ie *IndexError
+	if gs != nil || !errors.As(err, &ie) || ie.Index != 2 || !errors.Is(err, ErrEmptyName) {
+		t.Fatalf("HellosConcurrent(%q) = %v, %v, want nil, *IndexError for index 2", names, gs, err)
+	}
+}
+
+func TestHellosConcurrentLowestError(t *testing.T) {
+	names := make([]string, 1000)
+	for i := range names {
+		names[i] = fmt.Sprintf("name%d", i)
+	}
+	for _, i := range []int{900, 37, 500, 38} {
+		names[i] = ""
+	}
+
+	// whichever worker fails first, the error is the one for index 37,
+	// just as HellosOrdered reports it
+	_, want := HellosOrdered(names)
+	for run := 0; run < 50; run++ {
+		_, err := HellosConcurrent(context.Background(), names, 8)
+		var ie *IndexError
+		if !errors.As(err, &ie) || ie.Index != 37 || err.Error() != want.Error() {
+			t.Fatalf("run %d: HellosConcurrent error = %v, want %v", run, err, want)
+		}
+	}
+}
+
+func TestHellosConcurrentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	gs, err := HellosConcurrent(ctx, []string{"Gladys", "Samantha"}, 1)
+	if gs != nil || !errors.Is(err, context.Canceled) {
+		t.Fatalf("HellosConcurrent with canceled context = %v, %v, want nil, context.Canceled", gs, err)
+	}
+}
+
+func TestHellosConcurrentEmpty(t *testing.T) {
+	gs, err := HellosConcurrent(context.Background(), nil, 4)
+	if len(gs) != 0 || err != nil {
+		t.Fatalf("HellosConcurrent(nil) = %v, %v, want empty, nil", gs, err)
+	}
+}
@@ -1,3 +1,5 @@
 module example.com/greetings
 
 go 1.21.3
+
+require golang.org/x/text v0.14.0
@@ -1,43 +1,121 @@
 package greetings
 
-import (
-	"errors"
-	"fmt"
-	"math/rand"
-)
+import "time"
+
+// Greeter builds greetings, using its Selector to pick among the
+// formats of a locale and its Policy to clean up names. The zero value
+// selects at random from the global math/rand source.
+type Greeter struct {
+	Selector Selector
+	Policy   *NamePolicy // DefaultNamePolicy when nil
+}
+
+// NewGreeter returns a Greeter that picks formats with s.
+func NewGreeter(s Selector) *Greeter {
+	return &Greeter{Selector: s}
+}
+
+// defaultGreeter backs the package level functions.
+var defaultGreeter = &Greeter{}
 
 // Hello returns a greeting string for the name string
 func Hello(name string) (string, error) {
-	if name == "" {
-		return "", errors.New("empty name")
-	}
+	return defaultGreeter.Hello(name)
+}
 
-	// create a msg using random format
-	message := fmt.Sprintf(randomFormat(), name)
+// HelloIn returns a greeting for name using the catalog of locale.
+func HelloIn(locale, name string) (string, error) {
+	return defaultGreeter.HelloIn(locale, name)
+}
 
-	return message, nil
+// Greet renders a greeting for r.
+func Greet(r Recipient) (string, error) {
+	return defaultGreeter.Greet(r)
 }
 
 func Hellos(names []string) (map[string]string, error) {
-	messages := make(map[string]string)
+	return defaultGreeter.Hellos(names)
+}
+
+// HellosIn returns a map of greetings for names using the catalog of locale.
+func HellosIn(locale string, names []string) (map[string]string, error) {
+	return defaultGreeter.HellosIn(locale, names)
+}
+
+// Hello returns a greeting for name in the DefaultLocale.
+func (g *Greeter) Hello(name string) (string, error) {
+	return g.HelloIn(DefaultLocale, name)
+}
+
+// HelloIn returns a greeting for name using the catalog of locale.
+// An empty locale is invalid, like any other malformed tag.
+func (g *Greeter) HelloIn(locale, name string) (string, error) {
+	_, message, err := g.greet(Recipient{Name: name, Locale: locale})
+	return message, err
+}
 
-	for _, name := range names {
-		message, err := Hello(name)
-		if err != nil {
-			return nil, err
-		}
-		messages[name] = message
+// Greet renders a greeting for r using a format picked from the
+// catalog of r.Locale, or of DefaultLocale if r.Locale is empty.
+func (g *Greeter) Greet(r Recipient) (string, error) {
+	if r.Locale == "" {
+		r.Locale = DefaultLocale
 	}
+	_, message, err := g.greet(r)
+	return message, err
+}
 
-	return messages, nil
+// greet is Greet without the default locale, also returning the
+// normalized name.
+func (g *Greeter) greet(r Recipient) (string, string, error) {
+	name, err := g.policy().Normalize(r.Name)
+	if err != nil {
+		return "", "", err
+	}
+	r.Name = name
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+
+	formats, err := lookupFormats(r.Locale)
+	if err != nil {
+		return "", "", err
+	}
+
+	// create a msg using the selected format
+	message, err := formats[g.selector().Select(r.Name, len(formats))].render(r)
+	if err != nil {
+		return "", "", err
+	}
+
+	return r.Name, message, nil
 }
 
-func randomFormat() string {
-	var formats = []string{
-		"Hi, %v, Welcome!",
-		"Great to see you, %v!",
-		"Hail, %v! Well met!",
+// Hellos returns a map of greetings for names in the DefaultLocale.
+func (g *Greeter) Hellos(names []string) (map[string]string, error) {
+	return g.HellosIn(DefaultLocale, names)
+}
+
+// HellosIn returns a map of greetings for names using the catalog of
+// locale, keyed by the normalized names.
+func (g *Greeter) HellosIn(locale string, names []string) (map[string]string, error) {
+	gs, err := g.HellosOrderedIn(locale, names)
+	if err != nil {
+		return nil, err
 	}
 
-	return formats[rand.Intn(len(formats))]
+	return gs.Map(), nil
+}
+
+func (g *Greeter) policy() *NamePolicy {
+	if g.Policy == nil {
+		return &DefaultNamePolicy
+	}
+	return g.Policy
+}
+
+func (g *Greeter) selector() Selector {
+	if g.Selector == nil {
+		return globalRandom{}
+	}
+	return g.Selector
 }
@@ -1,7 +1,9 @@
 package greetings
 
 import (
+	"errors"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -23,4 +25,62 @@ func TestHelloEmpty(t *testing.T) {
 	}
 }
 
- 
\ No newline at end of file
+func TestHelloInFallback(t *testing.T) {
+	if err := RegisterCatalog("pt", []string{"Olá, %v!"}); err != nil {
+		t.Fatalf(`RegisterCatalog("pt") = %v, want nil`, err)
+	}
+
+	msg, err := HelloIn("pt-BR", "Gladys")
+	if msg != "Olá, Gladys!" || err != nil {
+		t.Fatalf(`HelloIn("pt-BR", "Gladys") = %q, %v, want "Olá, Gladys!", nil`, msg, err)
+	}
+}
+
+func TestHelloInUnknownLocale(t *testing.T) {
+	msg, err := HelloIn("xx-YY", "Gladys")
+	var unknown *UnknownLocaleError
+	if msg != "" || !errors.As(err, &unknown) {
+		t.Fatalf(`HelloIn("xx-YY", "Gladys") = %q, %v, want "", *UnknownLocaleError`, msg, err)
+	}
+}
+
+func TestHelloInInvalidLocale(t *testing.T) {
+	for _, locale := range []string{"", "e", "en--US", "en-US-", "en US"} {
+		_, err := HelloIn(locale, "Gladys")
+		var invalid *InvalidLocaleError
+		if !errors.As(err, &invalid) {
+			t.Fatalf(`HelloIn(%q, "Gladys") error = %v, want *InvalidLocaleError`, locale, err)
+		}
+	}
+}
+
+func TestLoadCatalogs(t *testing.T) {
+	bundle := `{"de": ["Hallo, %v!"], "DE_at": ["Servus, %v!"]}`
+	if err := LoadCatalogs(strings.NewReader(bundle)); err != nil {
+		t.Fatalf(`LoadCatalogs(%#q) = %v, want nil`, bundle, err)
+	}
+
+	msg, err := HelloIn("de-AT", "Gladys")
+	if msg != "Servus, Gladys!" || err != nil {
+		t.Fatalf(`HelloIn("de-AT", "Gladys") = %q, %v, want "Servus, Gladys!", nil`, msg, err)
+	}
+
+	msg, err = HelloIn("de-CH", "Gladys")
+	if msg != "Hallo, Gladys!" || err != nil {
+		t.Fatalf(`HelloIn("de-CH", "Gladys") = %q, %v, want "Hallo, Gladys!", nil`, msg, err)
+	}
+}
+
+func TestLoadCatalogsAtomic(t *testing.T) {
+	bundle := `{"sv": ["Hej, %v!"], "fi": ["Hei, %v!"], "xx": ["no verb"]}`
+	if err := LoadCatalogs(strings.NewReader(bundle)); err == nil {
+		t.Fatalf(`LoadCatalogs(%#q) = nil, want an error`, bundle)
+	}
+
+	for _, locale := range []string{"sv", "fi"} {
+		var unknown *UnknownLocaleError
+		if _, err := HelloIn(locale, "Gladys"); !errors.As(err, &unknown) {
+			t.Fatalf(`HelloIn(%q, "Gladys") error = %v, want *UnknownLocaleError`, locale, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package greetings
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Greeting is the greeting for the name at Index of a batch.
+type Greeting struct {
+	Name    string `json:"name"` // normalized name
+	Message string `json:"message"`
+	Index   int    `json:"index"`
+}
+
+func (g Greeting) String() string {
+	return g.Message
+}
+
+// Greetings is an ordered batch of greetings. Unlike the map returned by
+// Hellos it keeps the input order and duplicate names.
+type Greetings []Greeting
+
+// Map returns the greetings keyed by name; for duplicate names the
+// last greeting wins.
+func (gs Greetings) Map() map[string]string {
+	messages := make(map[string]string, len(gs))
+	for _, g := range gs {
+		messages[g.Name] = g.Message
+	}
+	return messages
+}
+
+// MarshalText returns the messages, one per line.
+func (gs Greetings) MarshalText() ([]byte, error) {
+	var b strings.Builder
+	for _, g := range gs {
+		b.WriteString(g.Message)
+		b.WriteByte('\n')
+	}
+	return []byte(b.String()), nil
+}
+
+// MarshalJSON encodes the greetings as an array of objects. It is needed
+// so that encoding/json does not fall back to MarshalText.
+func (gs Greetings) MarshalJSON() ([]byte, error) {
+	if gs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Greeting(gs))
+}
+
+// HellosOrdered is like Hellos but returns the greetings in input order.
+func HellosOrdered(names []string) (Greetings, error) {
+	return defaultGreeter.HellosOrdered(names)
+}
+
+// HellosOrderedIn is like HellosIn but returns the greetings in input order.
+func HellosOrderedIn(locale string, names []string) (Greetings, error) {
+	return defaultGreeter.HellosOrderedIn(locale, names)
+}
+
+// HellosOrdered is like Hellos but returns the greetings in input order.
+func (g *Greeter) HellosOrdered(names []string) (Greetings, error) {
+	return g.HellosOrderedIn(DefaultLocale, names)
+}
+
+// HellosOrderedIn returns one Greeting per name, in the order of names,
+// using the catalog of locale. It stops at the first name that cannot
+// be greeted and returns it as an *IndexError.
+func (g *Greeter) HellosOrderedIn(locale string, names []string) (Greetings, error) {
+	return g.greetAll(locale, names, false)
+}
+
+// greetAll greets names in order. It stops at the first failure, as an
+// *IndexError, unless keepGoing is set, in which case it returns an
+// errors.Join of one *IndexError per failed name.
+func (g *Greeter) greetAll(locale string, names []string, keepGoing bool) (Greetings, error) {
+	gs := make(Greetings, 0, len(names))
+	var errs []error
+
+	for i, name := range names {
+		key, message, err := g.greet(Recipient{Name: name, Locale: locale})
+		if err != nil {
+			err = &IndexError{Index: i, Name: name, Err: err}
+			if !keepGoing {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		gs = append(gs, Greeting{Name: key, Message: message, Index: i})
+	}
+
+	return gs, errors.Join(errs...)
+}
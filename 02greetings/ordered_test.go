@@ -0,0 +1,54 @@
+package greetings
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHellosOrdered(t *testing.T) {
+	g := NewGreeter(&RoundRobinSelector{})
+	names := []string{"Samantha", "Gladys", "Samantha"}
+
+	gs, err := g.HellosOrdered(names)
+	if err != nil {
+		t.Fatalf("HellosOrdered(%q) error = %v, want nil", names, err)
+	}
+
+	want := Greetings{
+		{Name: "Samantha", Message: "Hi, Samantha, Welcome!", Index: 0},
+		{Name: "Gladys", Message: "Great to see you, Gladys!", Index: 1},
+		{Name: "Samantha", Message: "Hail, Samantha! Well met!", Index: 2},
+	}
+	if len(gs) != len(want) {
+		t.Fatalf("HellosOrdered(%q) = %v, want %v", names, gs, want)
+	}
+	for i := range want {
+		if gs[i] != want[i] {
+			t.Fatalf("HellosOrdered(%q)[%d] = %+v, want %+v", names, i, gs[i], want[i])
+		}
+	}
+}
+
+func TestGreetingsMarshal(t *testing.T) {
+	gs := Greetings{
+		{Name: "Gladys", Message: "Hi, Gladys, Welcome!", Index: 0},
+		{Name: "Samantha", Message: "Hail, Samantha! Well met!", Index: 1},
+	}
+
+	text, err := gs.MarshalText()
+	if want := "Hi, Gladys, Welcome!\nHail, Samantha! Well met!\n"; string(text) != want || err != nil {
+		t.Fatalf("MarshalText() = %q, %v, want %q, nil", text, err, want)
+	}
+
+	b, err := json.Marshal(gs)
+	want := `[{"name":"Gladys","message":"Hi, Gladys, Welcome!","index":0},` +
+		`{"name":"Samantha","message":"Hail, Samantha! Well met!","index":1}]`
+	if string(b) != want || err != nil {
+		t.Fatalf("json.Marshal = %s, %v, want %s, nil", b, err, want)
+	}
+
+	var back Greetings
+	if err := json.Unmarshal(b, &back); err != nil || len(back) != 2 || back[1] != gs[1] {
+		t.Fatalf("json.Unmarshal(%s) = %+v, %v, want %+v", b, back, err, gs)
+	}
+}
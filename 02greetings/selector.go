@@ -0,0 +1,116 @@
+package greetings
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+)
+
+// Selector picks which of n greeting formats to use for name.
+// Select must return an index in [0, n).
+type Selector interface {
+	Select(name string, n int) int
+}
+
+// globalRandom selects using the shared math/rand source.
+type globalRandom struct{}
+
+func (globalRandom) Select(name string, n int) int {
+	return rand.Intn(n)
+}
+
+// RandomSelector picks formats uniformly at random from its own source,
+// so a fixed seed gives a reproducible sequence of greetings.
+type RandomSelector struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewRandomSelector returns a RandomSelector drawing from src.
+func NewRandomSelector(src rand.Source) *RandomSelector {
+	return &RandomSelector{rng: rand.New(src)}
+}
+
+func (s *RandomSelector) Select(name string, n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rng.Intn(n)
+}
+
+// RoundRobinSelector cycles through the formats in order.
+// The zero value starts at the first format.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	next int
+}
+
+func (s *RoundRobinSelector) Select(name string, n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.next % n
+	s.next = i + 1
+
+	return i
+}
+
+// HashSelector picks a format from a hash of the name, so the same
+// name always gets the same greeting.
+type HashSelector struct{}
+
+func (HashSelector) Select(name string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+
+	return int(h.Sum32() % uint32(n))
+}
+
+// WeightedSelector picks format i with probability proportional to
+// its weight. Formats without a weight count as weight 1.
+type WeightedSelector struct {
+	mu      sync.Mutex
+	rng     *rand.Rand
+	weights []int
+}
+
+// NewWeightedSelector returns a WeightedSelector drawing from src.
+// Negative weights are treated as 0.
+func NewWeightedSelector(weights []int, src rand.Source) *WeightedSelector {
+	w := make([]int, len(weights))
+	for i, v := range weights {
+		w[i] = max(v, 0)
+	}
+
+	return &WeightedSelector{rng: rand.New(src), weights: w}
+}
+
+func (s *WeightedSelector) Select(name string, n int) int {
+	total := 0
+	for i := 0; i < n; i++ {
+		total += s.weight(i)
+	}
+	if total == 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	r := s.rng.Intn(total)
+	s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		r -= s.weight(i)
+		if r < 0 {
+			return i
+		}
+	}
+
+	return n - 1
+}
+
+func (s *WeightedSelector) weight(i int) int {
+	if i < len(s.weights) {
+		return s.weights[i]
+	}
+	return 1
+}
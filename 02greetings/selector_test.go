@@ -0,0 +1,62 @@
+package greetings
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRoundRobinSelector(t *testing.T) {
+	g := NewGreeter(&RoundRobinSelector{})
+	want := []string{
+		"Hi, Gladys, Welcome!",
+		"Great to see you, Gladys!",
+		"Hail, Gladys! Well met!",
+		"Hi, Gladys, Welcome!",
+	}
+
+	for _, w := range want {
+		msg, err := g.Hello("Gladys")
+		if msg != w || err != nil {
+			t.Fatalf(`Hello("Gladys") = %q, %v, want %q, nil`, msg, err, w)
+		}
+	}
+}
+
+func TestHashSelector(t *testing.T) {
+	g := NewGreeter(HashSelector{})
+
+	first, err := g.Hello("Gladys")
+	if err != nil {
+		t.Fatalf(`Hello("Gladys") = %q, %v, want nil error`, first, err)
+	}
+	for i := 0; i < 10; i++ {
+		msg, err := g.Hello("Gladys")
+		if msg != first || err != nil {
+			t.Fatalf(`Hello("Gladys") = %q, %v, want %q, nil`, msg, err, first)
+		}
+	}
+}
+
+func TestRandomSelectorSeeded(t *testing.T) {
+	a := NewGreeter(NewRandomSelector(rand.NewSource(42)))
+	b := NewGreeter(NewRandomSelector(rand.NewSource(42)))
+
+	for i := 0; i < 10; i++ {
+		x, _ := a.Hello("Gladys")
+		y, _ := b.Hello("Gladys")
+		if x != y {
+			t.Fatalf("greeting %d: %q != %q with the same seed", i, x, y)
+		}
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	g := NewGreeter(NewWeightedSelector([]int{0, 1, 0}, rand.NewSource(1)))
+
+	for i := 0; i < 10; i++ {
+		msg, err := g.Hello("Gladys")
+		if msg != "Great to see you, Gladys!" || err != nil {
+			t.Fatalf(`Hello("Gladys") = %q, %v, want "Great to see you, Gladys!", nil`, msg, err)
+		}
+	}
+}
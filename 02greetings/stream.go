@@ -0,0 +1,102 @@
+package greetings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HellosStream is like HellosStreamIn in the DefaultLocale.
+func HellosStream(r io.Reader, w io.Writer) error {
+	return defaultGreeter.HellosStream(r, w)
+}
+
+// HellosStream is like HellosStreamIn in the DefaultLocale.
+func (g *Greeter) HellosStream(r io.Reader, w io.Writer) error {
+	return g.HellosStreamIn(DefaultLocale, r, w)
+}
+
+// HellosStreamIn reads newline-delimited names from r and writes their
+// greetings to w, one per line, as it goes. Memory use does not depend
+// on the number of names. It stops at the first name that cannot be
+// greeted and returns it as an *IndexError.
+func (g *Greeter) HellosStreamIn(locale string, r io.Reader, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	err := g.StreamIn(locale, r, func(gr Greeting, err error) error {
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(bw, gr.Message)
+		return err
+	})
+	if err != nil {
+		// flush what was greeted before the failure
+		bw.Flush()
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// maxLineBytes bounds the memory StreamIn uses per line. It is far more
+// than DefaultNamePolicy accepts, so only garbage input reaches it.
+const maxLineBytes = 64 << 10
+
+// StreamIn reads newline-delimited names from r and calls yield once per
+// line, in order, with either its greeting or an *IndexError whose Index
+// is the zero-based line number. A trailing "\r" is dropped from each
+// line, and lines longer than 64 KiB are reported as ErrNameTooLong
+// without being read into memory. StreamIn stops and returns the error
+// if yield returns one, or if reading r fails.
+func (g *Greeter) StreamIn(locale string, r io.Reader, yield func(Greeting, error) error) error {
+	br := bufio.NewReaderSize(r, maxLineBytes)
+
+	for i := 0; ; i++ {
+		line, err := br.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			// skip the rest of the line, keeping only the error
+			for err == bufio.ErrBufferFull {
+				_, err = br.ReadSlice('\n')
+			}
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("reading names: %w", err)
+			}
+			tooLong := fmt.Errorf("%w: line is longer than %d bytes", ErrNameTooLong, maxLineBytes)
+			if err := yield(Greeting{}, &IndexError{Index: i, Err: tooLong}); err != nil {
+				return err
+			}
+		} else if len(line) > 0 {
+			name := strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r")
+			if err := g.yieldOne(locale, i, name, yield); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("reading names: %w", err)
+		}
+	}
+}
+
+// EachIn is StreamIn for names already in memory.
+func (g *Greeter) EachIn(locale string, names []string, yield func(Greeting, error) error) error {
+	for i, name := range names {
+		if err := g.yieldOne(locale, i, name, yield); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Greeter) yieldOne(locale string, i int, name string, yield func(Greeting, error) error) error {
+	key, message, err := g.greet(Recipient{Name: name, Locale: locale})
+	if err != nil {
+		return yield(Greeting{}, &IndexError{Index: i, Name: name, Err: err})
+	}
+	return yield(Greeting{Name: key, Message: message, Index: i}, nil)
+}
@@ -0,0 +1,72 @@
+package greetings
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHellosStream(t *testing.T) {
+	g := NewGreeter(&RoundRobinSelector{})
+	var b strings.Builder
+
+	err := g.HellosStream(strings.NewReader("Gladys\r\nSamantha\nDarrin"), &b)
+	want := "Hi, Gladys, Welcome!\nGreat to see you, Samantha!\nHail, Darrin! Well met!\n"
+	if b.String() != want || err != nil {
+		t.Fatalf("HellosStream = %q, %v, want %q, nil", b.String(), err, want)
+	}
+}
+
+func TestHellosStreamError(t *testing.T) {
+	g := NewGreeter(&RoundRobinSelector{})
+	var b strings.Builder
+
+	err := g.HellosStream(strings.NewReader("Gladys\n\nSamantha\n"), &b)
+	var ie *IndexError
+	if !errors.As(err, &ie) || ie.Index != 1 || !errors.Is(err, ErrEmptyName) {
+		t.Fatalf("HellosStream error = %v, want *IndexError for line 1", err)
+	}
+	if want := "Hi, Gladys, Welcome!\n"; b.String() != want {
+		t.Fatalf("HellosStream wrote %q before failing, want %q", b.String(), want)
+	}
+}
+
+func TestStreamInLenient(t *testing.T) {
+	var got []int
+	var failed []int
+
+	err := defaultGreeter.StreamIn("en", strings.NewReader("Gladys\n\nSamantha\n"), func(gr Greeting, err error) error {
+		var ie *IndexError
+		if errors.As(err, &ie) {
+			failed = append(failed, ie.Index)
+			return nil
+		}
+		got = append(got, gr.Index)
+		return nil
+	})
+	if err != nil || len(got) != 2 || got[1] != 2 || len(failed) != 1 || failed[0] != 1 {
+		t.Fatalf("StreamIn = %v, greeted %v, failed %v, want nil, [0 2], [1]", err, got, failed)
+	}
+}
+
+func TestStreamInLongLine(t *testing.T) {
+	var got []string
+	var failed []int
+
+	long := strings.Repeat("x", 3*maxLineBytes)
+	err := defaultGreeter.StreamIn("en", strings.NewReader("Gladys\n"+long+"\r\nSamantha"), func(gr Greeting, err error) error {
+		if err != nil {
+			var ie *IndexError
+			if !errors.As(err, &ie) || !errors.Is(err, ErrNameTooLong) {
+				t.Fatalf("StreamIn error = %v, want *IndexError with ErrNameTooLong", err)
+			}
+			failed = append(failed, ie.Index)
+			return nil
+		}
+		got = append(got, gr.Name)
+		return nil
+	})
+	if err != nil || len(got) != 2 || got[0] != "Gladys" || got[1] != "Samantha" || len(failed) != 1 || failed[0] != 1 {
+		t.Fatalf("StreamIn = %v, greeted %q, failed %v, want nil, [Gladys Samantha], [1]", err, got, failed)
+	}
+}
@@ -0,0 +1,58 @@
+package greetings
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Recipient is the structured data a greeting is rendered from.
+type Recipient struct {
+	Name   string
+	Title  string    // e.g. "Dr."; optional
+	Locale string    // BCP 47 tag; DefaultLocale when empty
+	Time   time.Time // time of the greeting; time.Now() when zero
+}
+
+// format renders one greeting for a recipient.
+type format interface {
+	render(r Recipient) (string, error)
+}
+
+// sprintfFormat is a fmt format with a single %v for the name.
+type sprintfFormat string
+
+func (f sprintfFormat) render(r Recipient) (string, error) {
+	return fmt.Sprintf(string(f), r.Name), nil
+}
+
+// templateFormat is a text/template executed with the Recipient.
+type templateFormat struct {
+	tmpl *template.Template
+}
+
+func (f templateFormat) render(r Recipient) (string, error) {
+	var b strings.Builder
+	if err := f.tmpl.Execute(&b, r); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// templateFuncs are available to every registered template.
+var templateFuncs = template.FuncMap{
+	"timeOfDay": timeOfDay,
+}
+
+// timeOfDay returns "morning", "afternoon" or "evening" for t.
+func timeOfDay(t time.Time) string {
+	switch h := t.Hour(); {
+	case h >= 5 && h < 12:
+		return "morning"
+	case h >= 12 && h < 18:
+		return "afternoon"
+	default:
+		return "evening"
+	}
+}
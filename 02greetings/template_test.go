@@ -0,0 +1,65 @@
+package greetings
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGreetTemplate(t *testing.T) {
+	err := RegisterTemplates("en-GB", "Good {{timeOfDay .Time}}, {{with .Title}}{{.}} {{end}}{{.Name}}!")
+	if err != nil {
+		t.Fatalf("RegisterTemplates = %v, want nil", err)
+	}
+
+	tests := []struct {
+		r    Recipient
+		want string
+	}{
+		{Recipient{Name: "Gladys", Locale: "en-GB", Time: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}, "Good morning, Gladys!"},
+		{Recipient{Name: "Gladys", Title: "Dr.", Locale: "en-GB", Time: time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)}, "Good afternoon, Dr. Gladys!"},
+		{Recipient{Name: "Gladys", Locale: "en-GB", Time: time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)}, "Good evening, Gladys!"},
+	}
+
+	for _, tt := range tests {
+		msg, err := Greet(tt.r)
+		if msg != tt.want || err != nil {
+			t.Fatalf("Greet(%+v) = %q, %v, want %q, nil", tt.r, msg, err, tt.want)
+		}
+	}
+}
+
+func TestRegisterTemplatesParseError(t *testing.T) {
+	if err := RegisterTemplates("en-AU", "G'day, {{.Name"); err == nil {
+		t.Fatal("RegisterTemplates with unterminated action = nil, want error")
+	}
+}
+
+func TestRegisterTemplatesEmpty(t *testing.T) {
+	if err := RegisterTemplates("fr"); err == nil {
+		t.Fatal(`RegisterTemplates("fr") with no templates = nil, want error`)
+	}
+	if _, err := HelloIn("fr", "Gladys"); err == nil {
+		t.Fatal(`HelloIn("fr", "Gladys") found an empty catalog`)
+	}
+
+	// an empty catalog is skipped in favour of its fallback
+	if err := RegisterCatalog("nl", []string{"Hallo, %v!"}); err != nil {
+		t.Fatal(err)
+	}
+	catalogsMu.Lock()
+	catalogs["nl-BE"] = nil
+	catalogsMu.Unlock()
+
+	msg, err := HelloIn("nl-BE", "Gladys")
+	if msg != "Hallo, Gladys!" || err != nil {
+		t.Fatalf(`HelloIn("nl-BE", "Gladys") = %q, %v, want "Hallo, Gladys!", nil`, msg, err)
+	}
+}
+
+func TestGreetDefaultLocale(t *testing.T) {
+	msg, err := Greet(Recipient{Name: "Gladys"})
+	if !strings.Contains(msg, "Gladys") || err != nil {
+		t.Fatalf(`Greet(Recipient{Name: "Gladys"}) = %q, %v, want a greeting in %q`, msg, err, DefaultLocale)
+	}
+}
@@ -0,0 +1,66 @@
+package greetings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Errors returned when a name fails the NamePolicy of a Greeter.
+var (
+	ErrEmptyName      = errors.New("empty name")
+	ErrInvalidUTF8    = errors.New("name is not valid UTF-8")
+	ErrNameTooLong    = errors.New("name too long")
+	ErrDisallowedRune = errors.New("name contains a disallowed rune")
+)
+
+// NamePolicy describes how names are normalized and validated before
+// they are greeted.
+type NamePolicy struct {
+	TrimSpace bool            // strip leading and trailing white space
+	NFC       bool            // convert to Unicode normalization form C
+	MaxRunes  int             // longest accepted name; 0 means no limit
+	Disallow  func(rune) bool // reports runes that may not appear; nil allows all
+}
+
+// DefaultNamePolicy is used by Greeters without a Policy.
+var DefaultNamePolicy = NamePolicy{
+	TrimSpace: true,
+	NFC:       true,
+	MaxRunes:  256,
+	Disallow:  unicode.IsControl,
+}
+
+// Normalize returns name cleaned up according to p, or an error
+// matching one of the Err* sentinels if it is not acceptable.
+func (p NamePolicy) Normalize(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", ErrInvalidUTF8
+	}
+	if p.TrimSpace {
+		name = strings.TrimSpace(name)
+	}
+	if p.NFC {
+		name = norm.NFC.String(name)
+	}
+
+	if name == "" {
+		return "", ErrEmptyName
+	}
+	if n := utf8.RuneCountInString(name); p.MaxRunes > 0 && n > p.MaxRunes {
+		return "", fmt.Errorf("%w: %d runes, limit is %d", ErrNameTooLong, n, p.MaxRunes)
+	}
+	if p.Disallow != nil {
+		for i, r := range name {
+			if p.Disallow(r) {
+				return "", fmt.Errorf("%w: %U at byte %d", ErrDisallowedRune, r, i)
+			}
+		}
+	}
+
+	return name, nil
+}
@@ -0,0 +1,47 @@
+package greetings
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		err  error
+	}{
+		{"Gladys", "Gladys", nil},
+		{"  Gladys\t", "Gladys", nil},
+		{"Jose\u0301", "Jos\u00e9", nil},
+		{"", "", ErrEmptyName},
+		{" \t\n", "", ErrEmptyName},
+		{"Gla\x00dys", "", ErrDisallowedRune},
+		{"Gla\xffdys", "", ErrInvalidUTF8},
+		{strings.Repeat("a", 257), "", ErrNameTooLong},
+	}
+
+	for _, tt := range tests {
+		got, err := DefaultNamePolicy.Normalize(tt.name)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Fatalf("Normalize(%q) = %q, %v, want %q, %v", tt.name, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestHellosNormalizedKeys(t *testing.T) {
+	messages, err := Hellos([]string{"Jos\u00e9", "Jose\u0301", " Jos\u00e9 "})
+	if len(messages) != 1 || messages["Jos\u00e9"] == "" || err != nil {
+		t.Fatalf("Hellos = %q, %v, want one greeting keyed %q", messages, err, "Jos\u00e9")
+	}
+}
+
+func TestGreeterPolicy(t *testing.T) {
+	g := &Greeter{Policy: &NamePolicy{MaxRunes: 3}}
+
+	_, err := g.Hello("Gladys")
+	if !errors.Is(err, ErrNameTooLong) {
+		t.Fatalf(`Hello("Gladys") error = %v, want ErrNameTooLong`, err)
+	}
+}
@@ -2,19 +2,25 @@ package main
 
 import (
 	"fmt"
+	"os"
+
+	"example.com/numeric"
 )
 
-func Sqrt(x float64) float64 {
-	z := 1000.0
+func main() {
+	//Newton's method now lives in the numeric package: it starts from a
+	//guess near the answer and stops once the steps are small enough
+	//instead of always running 10 iterations
+	opts := numeric.DefaultOptions
 
-	for i := 1; i <= 10; i++ {
-		fmt.Println("guess is ", z)
-		z -= (z - (x / z)) / 2
-	}
+	//the guesses are no longer printed by Sqrt itself, a Tracer is told
+	//about every iteration and here it prints them to stdout
+	opts.Tracer = numeric.NewWriterTracer(os.Stdout)
 
-	return z
-}
+	res, err := numeric.SqrtWith(36, opts)
+	if err != nil {
+		fmt.Println(err)
+	}
 
-func main() {
-	fmt.Println(Sqrt(36))
+	fmt.Println(res.Value, "after", res.Iterations, "iterations, last step", res.Delta)
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"strings"
+
+	"example.com/collections/treemap"
 )
 
 func ptr() {
@@ -169,18 +171,22 @@ func SliceExample() {
 	printSlice("d2", d2)
 }
 
-func printMap(mp map[string]int) {
-	for k, v := range mp {
+// ranging over a built-in map visits the keys in random order, so for a
+// report that comes out the same every time the counts go in a treemap,
+// which keeps its keys sorted
+func printMap(mp *treemap.Map[string, int]) {
+	mp.All()(func(k string, v int) bool {
 		fmt.Println(k, v)
-	}
+		return true
+	})
 }
 
-func wordCount(s string) map[string]int {
+func wordCount(s string) *treemap.Map[string, int] {
 	words := strings.Split(s, " ")
 
-	mp := make(map[string]int)
+	mp := treemap.New[string, int]()
 	for _, w := range words {
-		mp[w]++
+		mp.Update(w, func(n int, _ bool) int { return n + 1 })
 	}
 
 	return mp
@@ -1,13 +1,16 @@
 package main
 
 import (
-	// "errors"
+	"errors"
 	"fmt"
 	"image"
 	"io"
 	"math"
+	"math/cmplx"
 	"strings"
 	"time"
+
+	"example.com/numeric"
 )
 
 /*
@@ -336,25 +339,102 @@ func errorInterface() {
 type ErrDataNegativeSqrt float64
 
 func (e ErrDataNegativeSqrt) Error() string {
-	return fmt.Sprintf(`
-ERROR: negative-square-root not implemented yet, maybe try some other libraries/package?
-error for number %v`,
+	return fmt.Sprintf("no real square root of %v, use SqrtComplexMode for a complex one",
 		float64(e))
 	//    ^--Here conversion must be done to avoid recursion in case of error
 }
 
 func Sqrt(x float64) (float64, error) {
 	if x < 0 {
-		return 0, ErrDataNegativeSqrt(x)
+		return 0, negativeSqrtError(x)
 	}
 	return math.Sqrt(x), nil
 }
 
+// negativeSqrtError wraps ErrDataNegativeSqrt in a numeric.Error, so
+// callers can check it like any other domain error (see wrappedErrors)
+func negativeSqrtError(x float64) error {
+	return &numeric.Error{
+		Op:    "Sqrt",
+		Input: x,
+		Kind:  numeric.ErrDomain,
+		Err:   ErrDataNegativeSqrt(x),
+	}
+}
+
 func errorExercise() {
 	fmt.Println(Sqrt(56))
 	fmt.Println(Sqrt(-222))
 }
 
+/* WRAPPING ERRORS
+- an error can wrap other errors by having an Unwrap() error (or Unwrap() []error) method
+- errors.Is(err, target) walks the wrapped errors looking for that exact value
+- errors.As(err, &target) walks them looking for one of target's type, and copies it into target
+
+- numeric.Error wraps the kind of failure (numeric.ErrDomain, numeric.ErrNoConvergence, numeric.ErrOverflow)
+    and the specific cause, and carries the operation and the offending input as fields
+    => no need to parse error strings to find out what went wrong
+*/
+
+func wrappedErrors() {
+	_, err := Sqrt(-222)
+	fmt.Println(err) //Sqrt(-222): domain error: no real square root of -222, ...
+
+	//is it a domain error? compares against the sentinel value
+	fmt.Println(errors.Is(err, numeric.ErrDomain)) //true
+
+	//get the structured error out to look at its fields
+	var numErr *numeric.Error
+	if errors.As(err, &numErr) {
+		fmt.Println(numErr.Op, numErr.Input) //Sqrt -222
+	}
+
+	//the cause is still our own error type from the exercise
+	var negErr ErrDataNegativeSqrt
+	if errors.As(err, &negErr) {
+		fmt.Println(float64(negErr)) //-222
+	}
+}
+
+/* COMPLEX SQUARE ROOTS
+- every number has two square roots in the complex plane, the principal one
+    is the root with a non-negative real part (Re(√z) >= 0)
+- for a negative x that is i*√|x|, e.g. √-4 = 2i
+- complex128 is a builtin type: complex(re, im) builds one, real() and imag() take it apart
+
+- SqrtMode lets the caller pick what happens to negative numbers:
+    keep the ErrDataNegativeSqrt error from Sqrt or get the complex root
+*/
+
+type SqrtMode int
+
+const (
+	SqrtErrorMode   SqrtMode = iota // negative inputs fail with ErrDataNegativeSqrt, like Sqrt
+	SqrtComplexMode                 // negative inputs give their principal complex root
+)
+
+// SqrtWithMode returns the square root of x as a complex128, so that both
+// modes have the same result type. For x >= 0 the imaginary part is 0.
+func SqrtWithMode(x float64, mode SqrtMode) (complex128, error) {
+	if x < 0 && mode == SqrtErrorMode {
+		return 0, negativeSqrtError(x)
+	}
+	return ComplexSqrt(complex(x, 0)), nil
+}
+
+// ComplexSqrt returns the principal square root of z.
+func ComplexSqrt(z complex128) complex128 {
+	return cmplx.Sqrt(z)
+}
+
+func complexSqrtExample() {
+	fmt.Println(SqrtWithMode(-4, SqrtErrorMode))
+	fmt.Println(SqrtWithMode(-4, SqrtComplexMode)) //(0+2i)
+	fmt.Println(ComplexSqrt(3 + 4i))               //(2+1i)
+	fmt.Println(ComplexSqrt(-3 - 4i))              //(1-2i)
+}
+
 /*
 # Reader Interface
 - The example code creates a strings.Reader and consumes its output 8 bytes at a time
@@ -395,6 +475,8 @@ func main() {
 	// stringerExample()
 	// errorInterface()
 	// errorExercise()
+	// wrappedErrors()
+	// complexSqrtExample()
 	// ReaderExample()
     ImageInterface()
 }
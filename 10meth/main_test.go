@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplexSqrt(t *testing.T) {
+	const big, tiny = math.MaxFloat64, math.SmallestNonzeroFloat64
+	tests := []complex128{
+		3 + 4i, -3 - 4i, 1i, -1i, 2, -4, 1e-10 + 1,
+		complex(big, big), complex(-big, big), complex(big, -1), complex(1, big),
+		complex(big/3, big/5), complex(tiny, tiny), complex(-tiny, 3*tiny), complex(1e-310, -1e-320),
+	}
+
+	for _, z := range tests {
+		got, want := ComplexSqrt(z), cmplx.Sqrt(z)
+		if cmplx.IsInf(got) || cmplx.IsNaN(got) || cmplx.Abs(got-want) > 1e-15*cmplx.Abs(want) {
+			t.Fatalf("ComplexSqrt(%g) = %g, want %g", z, got, want)
+		}
+	}
+}
+
+func TestComplexSqrtSpecialCases(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct{ z, want complex128 }{
+		{0, 0},
+		{complex(0, math.Copysign(0, -1)), complex(0, math.Copysign(0, -1))},
+		{complex(inf, 0), complex(inf, 0)},
+		{complex(nan, inf), complex(inf, inf)},
+		{complex(1, -inf), complex(inf, -inf)},
+	}
+
+	for _, tt := range tests {
+		got := ComplexSqrt(tt.z)
+		if got != tt.want || math.Signbit(imag(got)) != math.Signbit(imag(tt.want)) {
+			t.Fatalf("ComplexSqrt(%g) = %g, want %g", tt.z, got, tt.want)
+		}
+	}
+	if got := ComplexSqrt(complex(nan, 1)); !cmplx.IsNaN(got) {
+		t.Fatalf("ComplexSqrt(NaN+1i) = %g, want NaN", got)
+	}
+}
+
+func TestSqrtWithMode(t *testing.T) {
+	if _, err := SqrtWithMode(-4, SqrtErrorMode); !errors.As(err, new(ErrDataNegativeSqrt)) {
+		t.Fatalf("SqrtWithMode(-4, SqrtErrorMode) error = %v, want ErrDataNegativeSqrt", err)
+	}
+	if got, err := SqrtWithMode(-4, SqrtComplexMode); got != 2i || err != nil {
+		t.Fatalf("SqrtWithMode(-4, SqrtComplexMode) = %g, %v, want 2i", got, err)
+	}
+	if got, err := SqrtWithMode(9, SqrtErrorMode); got != 3 || err != nil {
+		t.Fatalf("SqrtWithMode(9, SqrtErrorMode) = %g, %v, want 3", got, err)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import "fmt"
+
+// minDequeCap is the smallest buffer a Deque allocates. Capacities are
+// powers of two so positions wrap with a mask instead of a division.
+const minDequeCap = 8
+
+// Deque is a double-ended queue of values of type T kept in a ring
+// buffer. Pushing and popping at either end are amortized O(1), and
+// unlike a linked list it supports O(1) access by index. The zero value
+// is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int // index in buf of the front element
+	len  int
+}
+
+// Len returns the number of elements in d.
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// PushBack adds v at the back of d.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.pos(d.len)] = v
+	d.len++
+}
+
+// PushFront adds v at the front of d.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopFront removes and returns the first element of d, or reports false
+// if d is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // let the GC have it
+	d.head = d.pos(1)
+	d.len--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns the last element of d, or reports false
+// if d is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	i := d.pos(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	d.shrink()
+	return v, true
+}
+
+// Front returns the first element of d, or reports false if d is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the last element of d, or reports false if d is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.pos(d.len-1)], true
+}
+
+// At returns the element at index i, counting from the front. It panics
+// if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.checkIndex(i)
+	return d.buf[d.pos(i)]
+}
+
+// Set replaces the element at index i. It panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	d.checkIndex(i)
+	d.buf[d.pos(i)] = v
+}
+
+// Clear removes all elements from d, keeping its buffer.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head, d.len = 0, 0
+}
+
+// All returns an iterator over the elements of d from front to back;
+// see List.All.
+func (d *Deque[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.len; i++ {
+			if !yield(d.buf[d.pos(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// pos returns the index in buf of the i-th element.
+func (d *Deque[T]) pos(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// grow makes room for one more element, doubling the buffer when full.
+func (d *Deque[T]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	d.resize(max(2*len(d.buf), minDequeCap))
+}
+
+// shrink halves the buffer once it is only a quarter full, so that a
+// deque that was briefly large does not hold on to its memory.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCap && d.len <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the elements to a new buffer of size n, front first.
+func (d *Deque[T]) resize(n int) {
+	buf := make([]T, n)
+	if d.head+d.len <= len(d.buf) {
+		copy(buf, d.buf[d.head:d.head+d.len])
+	} else {
+		k := copy(buf, d.buf[d.head:])
+		copy(buf[k:], d.buf[:d.len-k])
+	}
+	d.buf, d.head = buf, 0
+}
+
+func (d *Deque[T]) checkIndex(i int) {
+	if i < 0 || i >= d.len {
+		panic(fmt.Sprintf("deque: index %d out of range [0:%d]", i, d.len))
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"container/list"
+	"slices"
+	"testing"
+)
+
+// TestDequeModel applies a random sequence of operations to a Deque and
+// to a slice and checks after each one that they agree. The ops are
+// biased so the deque both grows past several doublings and shrinks.
+func TestDequeModel(t *testing.T) {
+	f := func(ops []uint8) bool {
+		var d Deque[int]
+		var model []int
+
+		for i, op := range ops {
+			switch op % 8 {
+			case 0, 1:
+				d.PushBack(i)
+				model = append(model, i)
+			case 2, 3:
+				d.PushFront(i)
+				model = slices.Insert(model, 0, i)
+			case 4:
+				v, ok := d.PopFront()
+				if ok != (len(model) > 0) || ok && v != model[0] {
+					return false
+				}
+				if ok {
+					model = model[1:]
+				}
+			case 5:
+				v, ok := d.PopBack()
+				if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+					return false
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			case 6:
+				if len(model) > 0 {
+					j := int(op>>3) % len(model)
+					d.Set(j, -i)
+					model[j] = -i
+				}
+			case 7:
+				if op&0x80 != 0 {
+					d.Clear()
+					model = model[:0]
+				}
+			}
+
+			for j, v := range model {
+				if d.At(j) != v {
+					return false
+				}
+			}
+			front, okf := d.Front()
+			back, okb := d.Back()
+			if d.Len() != len(model) || !sameEnds(model, front, okf, back, okb) {
+				return false
+			}
+		}
+		return true
+	}
+	quickCheck(t, f)
+}
+
+func TestDequeGrowShrink(t *testing.T) {
+	var d Deque[int]
+	for i := 0; i < 1000; i++ {
+		d.PushFront(i)
+	}
+	if len(d.buf) != 1024 {
+		t.Fatalf("capacity after 1000 pushes = %d, want 1024", len(d.buf))
+	}
+	for i := 0; i < 995; i++ {
+		if v, _ := d.PopBack(); v != i {
+			t.Fatalf("PopBack = %d, want %d", v, i)
+		}
+	}
+	// 5 elements keep the buffer above a quarter full at 16
+	if len(d.buf) != 16 {
+		t.Fatalf("capacity after popping = %d, want 16", len(d.buf))
+	}
+
+	if got := collect(d.All()); !slices.Equal(got, []int{999, 998, 997, 996, 995}) {
+		t.Fatalf("All = %v", got)
+	}
+}
+
+func TestDequeIndexPanics(t *testing.T) {
+	var d Deque[int]
+	d.PushBack(1)
+	mustPanic(t, map[string]func(){
+		"At(1)":  func() { d.At(1) },
+		"At(-1)": func() { d.At(-1) },
+		"Set(1)": func() { d.Set(1, 0) },
+	})
+}
+
+func BenchmarkDequeQueue(b *testing.B) {
+	var d Deque[int]
+	for i := 0; i < benchN; i++ {
+		d.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(i)
+		d.PopFront()
+	}
+}
+
+func BenchmarkContainerListQueue(b *testing.B) {
+	l := list.New()
+	for i := 0; i < benchN; i++ {
+		l.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.PushBack(i)
+		l.Remove(l.Front())
+	}
+}
+
+func BenchmarkDequeIterate(b *testing.B) {
+	var d Deque[int]
+	for i := 0; i < benchN; i++ {
+		d.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for j := 0; j < d.Len(); j++ {
+			sum += d.At(j)
+		}
+	}
+}
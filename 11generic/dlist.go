@@ -0,0 +1,165 @@
+package main
+
+// Element is a handle to a value stored in a DList. Keep it to remove
+// or move the value later in O(1).
+type Element[T any] struct {
+	next, prev *Element[T]
+	list       *DList[T] // nil once removed
+	Value      T
+}
+
+// Next returns the element after e, or nil at the back of the list.
+func (e *Element[T]) Next() *Element[T] {
+	if n := e.next; e.list != nil && n != &e.list.root {
+		return n
+	}
+	return nil
+}
+
+// Prev returns the element before e, or nil at the front of the list.
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// DList is a doubly linked list of values of type T, the generic
+// counterpart of container/list. The zero value is an empty list ready
+// to use. Every operation that is given an *Element is O(1).
+type DList[T any] struct {
+	root Element[T] // sentinel: root.next is the front, root.prev the back
+	len  int
+}
+
+// Len returns the number of elements in l.
+func (l *DList[T]) Len() int {
+	return l.len
+}
+
+// Front returns the first element of l, or nil if l is empty.
+func (l *DList[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element of l, or nil if l is empty.
+func (l *DList[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront adds v at the front of l and returns its element.
+func (l *DList[T]) PushFront(v T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: v}, &l.root)
+}
+
+// PushBack adds v at the back of l and returns its element.
+func (l *DList[T]) PushBack(v T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: v}, l.root.prev)
+}
+
+// InsertBefore adds v just before mark and returns its element. mark
+// must be an element of l.
+func (l *DList[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	l.mustOwn(mark)
+	return l.insert(&Element[T]{Value: v}, mark.prev)
+}
+
+// InsertAfter adds v just after mark and returns its element. mark must
+// be an element of l.
+func (l *DList[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	l.mustOwn(mark)
+	return l.insert(&Element[T]{Value: v}, mark)
+}
+
+// Remove removes e from l and returns its value. e must be an element
+// of l.
+func (l *DList[T]) Remove(e *Element[T]) T {
+	l.mustOwn(e)
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next, e.prev, e.list = nil, nil, nil
+	l.len--
+	return e.Value
+}
+
+// MoveToFront moves e to the front of l, as an LRU cache does on every
+// hit. e must be an element of l.
+func (l *DList[T]) MoveToFront(e *Element[T]) {
+	l.mustOwn(e)
+	l.move(e, &l.root)
+}
+
+// MoveToBack moves e to the back of l. e must be an element of l.
+func (l *DList[T]) MoveToBack(e *Element[T]) {
+	l.mustOwn(e)
+	l.move(e, l.root.prev)
+}
+
+// All returns an iterator over the values of l from front to back; see
+// List.All.
+func (l *DList[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for e := l.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of l from back to front.
+func (l *DList[T]) Backward() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for e := l.Back(); e != nil; e = e.Prev() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+func (l *DList[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// insert links e in after at and returns e.
+func (l *DList[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+// move unlinks e and links it back in after at.
+func (l *DList[T]) move(e, at *Element[T]) {
+	if e == at || e.prev == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+func (l *DList[T]) mustOwn(e *Element[T]) {
+	if e.list != l {
+		panic("dlist: element is not in this list")
+	}
+}
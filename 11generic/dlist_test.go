@@ -0,0 +1,159 @@
+package main
+
+import (
+	"container/list"
+	"slices"
+	"testing"
+)
+
+// TestDListModel applies a random sequence of operations to a DList and
+// to a slice, holding on to every element handle, and checks after each
+// one that they agree in both directions.
+func TestDListModel(t *testing.T) {
+	f := func(ops []uint8) bool {
+		var l DList[int]
+		var model []int
+		var handles []*Element[int] // handles[i] holds model[i]
+
+		for i, op := range ops {
+			pos := int(op>>3) % (len(model) + 1)
+			at := min(pos, len(model)-1)
+
+			switch op % 6 {
+			case 0:
+				handles = slices.Insert(handles, 0, l.PushFront(i))
+				model = slices.Insert(model, 0, i)
+			case 1:
+				handles = append(handles, l.PushBack(i))
+				model = append(model, i)
+			case 2:
+				if at >= 0 {
+					handles = slices.Insert(handles, at, l.InsertBefore(i, handles[at]))
+					model = slices.Insert(model, at, i)
+				}
+			case 3:
+				if at >= 0 {
+					handles = slices.Insert(handles, at+1, l.InsertAfter(i, handles[at]))
+					model = slices.Insert(model, at+1, i)
+				}
+			case 4:
+				if at >= 0 {
+					if l.Remove(handles[at]) != model[at] {
+						return false
+					}
+					handles = slices.Delete(handles, at, at+1)
+					model = slices.Delete(model, at, at+1)
+				}
+			case 5:
+				if at >= 0 {
+					e, v := handles[at], model[at]
+					handles = slices.Delete(handles, at, at+1)
+					model = slices.Delete(model, at, at+1)
+					if op&0x80 == 0 {
+						l.MoveToFront(e)
+						handles = slices.Insert(handles, 0, e)
+						model = slices.Insert(model, 0, v)
+					} else {
+						l.MoveToBack(e)
+						handles = append(handles, e)
+						model = append(model, v)
+					}
+				}
+			}
+
+			back := collect(l.Backward())
+			slices.Reverse(back)
+			if l.Len() != len(model) || !slices.Equal(collect(l.All()), model) || !slices.Equal(back, model) {
+				return false
+			}
+		}
+		return true
+	}
+	quickCheck(t, f)
+}
+
+func TestDListRemovedElement(t *testing.T) {
+	var l, other DList[string]
+	e := l.PushBack("a")
+	l.PushBack("b")
+	l.Remove(e)
+
+	if e.Next() != nil || e.Prev() != nil {
+		t.Fatal("removed element still linked")
+	}
+	if l.Front().Value != "b" || l.Front() != l.Back() {
+		t.Fatalf("list = %v", collect(l.All()))
+	}
+
+	mustPanic(t, map[string]func(){
+		"Remove twice":       func() { l.Remove(e) },
+		"MoveToFront other":  func() { other.MoveToFront(l.Front()) },
+		"InsertBefore other": func() { other.InsertBefore("x", l.Front()) },
+	})
+}
+
+func TestDListEmpty(t *testing.T) {
+	var l DList[int]
+	if l.Front() != nil || l.Back() != nil || l.Len() != 0 || len(collect(l.All())) != 0 {
+		t.Fatal("zero DList is not empty")
+	}
+}
+
+const benchN = 1024
+
+func BenchmarkDListLRU(b *testing.B) {
+	var l DList[int]
+	es := make([]*Element[int], benchN)
+	for i := range es {
+		es[i] = l.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a hit, then a miss evicting the least recently used
+		l.MoveToFront(es[(i*7919)%benchN])
+		slot := l.Remove(l.Back())
+		es[slot] = l.PushFront(slot)
+	}
+}
+
+func BenchmarkContainerListLRU(b *testing.B) {
+	l := list.New()
+	es := make([]*list.Element, benchN)
+	for i := range es {
+		es[i] = l.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.MoveToFront(es[(i*7919)%benchN])
+		slot := l.Remove(l.Back()).(int)
+		es[slot] = l.PushFront(slot)
+	}
+}
+
+func BenchmarkDListIterate(b *testing.B) {
+	var l DList[int]
+	for i := 0; i < benchN; i++ {
+		l.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for e := l.Front(); e != nil; e = e.Next() {
+			sum += e.Value
+		}
+	}
+}
+
+func BenchmarkContainerListIterate(b *testing.B) {
+	l := list.New()
+	for i := 0; i < benchN; i++ {
+		l.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for e := l.Front(); e != nil; e = e.Next() {
+			sum += e.Value.(int)
+		}
+	}
+}
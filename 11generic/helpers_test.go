@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+// quickCheck runs a testing/quick property, typically one that applies
+// random operations to a container and to a slice model side by side.
+func quickCheck(t *testing.T, f any) {
+	t.Helper()
+	if err := quick.Check(f, &quick.Config{MaxCount: 500}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sameEnds reports whether the results of Front and Back agree with the
+// first and last elements of model.
+func sameEnds[T comparable](model []T, front T, okFront bool, back T, okBack bool) bool {
+	if len(model) == 0 {
+		return !okFront && !okBack
+	}
+	return okFront && okBack && front == model[0] && back == model[len(model)-1]
+}
+
+// collect returns the values yielded by an All-style iterator.
+func collect[T any](all func(yield func(T) bool)) []T {
+	var s []T
+	all(func(v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// mustPanic fails unless each of the named funcs panics.
+func mustPanic(t *testing.T, funcs map[string]func()) {
+	t.Helper()
+	for name, f := range funcs {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import "fmt"
+
+// List is a singly linked list of values of type T. The zero value is an
+// empty list ready to use. Pushing and popping at the front, pushing at
+// the back and Len are O(1); everything else walks the list.
+type List[T any] struct {
+	head, tail *node[T]
+	len        int
+}
+
+type node[T any] struct {
+	next *node[T]
+	val  T
+}
+
+// FromSlice returns a list holding the elements of s in order.
+func FromSlice[T any](s []T) *List[T] {
+	l := &List[T]{}
+	for _, v := range s {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Slice returns the elements of l in order in a new slice.
+func (l *List[T]) Slice() []T {
+	s := make([]T, 0, l.len)
+	for n := l.head; n != nil; n = n.next {
+		s = append(s, n.val)
+	}
+	return s
+}
+
+// Len returns the number of elements in l.
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// PushFront adds v at the front of l.
+func (l *List[T]) PushFront(v T) {
+	l.head = &node[T]{next: l.head, val: v}
+	if l.tail == nil {
+		l.tail = l.head
+	}
+	l.len++
+}
+
+// PushBack adds v at the back of l.
+func (l *List[T]) PushBack(v T) {
+	n := &node[T]{val: v}
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.next = n
+	}
+	l.tail = n
+	l.len++
+}
+
+// PopFront removes and returns the first element of l, or reports false
+// if l is empty.
+func (l *List[T]) PopFront() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.Remove(0), true
+}
+
+// PopBack removes and returns the last element of l, or reports false
+// if l is empty. It is O(n): the node before the tail must be found.
+func (l *List[T]) PopBack() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.Remove(l.len - 1), true
+}
+
+// Front returns the first element of l, or reports false if l is empty.
+func (l *List[T]) Front() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.head.val, true
+}
+
+// Back returns the last element of l, or reports false if l is empty.
+func (l *List[T]) Back() (T, bool) {
+	if l.tail == nil {
+		var zero T
+		return zero, false
+	}
+	return l.tail.val, true
+}
+
+// At returns the element at index i. Like indexing a slice, it panics
+// if i is out of range.
+func (l *List[T]) At(i int) T {
+	l.checkIndex(i, l.len)
+	return l.nodeAt(i).val
+}
+
+// Insert adds v at index i, shifting the elements from i on back by one;
+// i may be Len() to append. It panics if i is out of range.
+func (l *List[T]) Insert(i int, v T) {
+	l.checkIndex(i, l.len+1)
+	switch i {
+	case 0:
+		l.PushFront(v)
+	case l.len:
+		l.PushBack(v)
+	default:
+		prev := l.nodeAt(i - 1)
+		prev.next = &node[T]{next: prev.next, val: v}
+		l.len++
+	}
+}
+
+// Remove removes and returns the element at index i. It panics if i is
+// out of range.
+func (l *List[T]) Remove(i int) T {
+	l.checkIndex(i, l.len)
+
+	var n *node[T]
+	if i == 0 {
+		n = l.head
+		l.head = n.next
+	} else {
+		prev := l.nodeAt(i - 1)
+		n = prev.next
+		prev.next = n.next
+		if n == l.tail {
+			l.tail = prev
+		}
+	}
+	if l.head == nil {
+		l.tail = nil
+	}
+	l.len--
+
+	n.next = nil // don't keep the rest of the list reachable
+	return n.val
+}
+
+// Reverse reverses the order of the elements of l in place.
+func (l *List[T]) Reverse() {
+	var prev *node[T]
+	l.tail = l.head
+	for n := l.head; n != nil; {
+		next := n.next
+		n.next = prev
+		prev, n = n, next
+	}
+	l.head = prev
+}
+
+// All returns an iterator over the elements of l in order. It works
+// like a Go 1.23 iter.Seq[T], with range-over-func where the toolchain
+// supports it and by calling it with a yield func on older ones:
+//
+//	l.All()(func(v T) bool { fmt.Println(v); return true })
+//
+// Unlike an iterator built on a channel it needs no goroutine and
+// nothing leaks when the loop stops early.
+func (l *List[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// String formats l like a slice, e.g. [1 2 3].
+func (l *List[T]) String() string {
+	return fmt.Sprint(l.Slice())
+}
+
+func (l *List[T]) nodeAt(i int) *node[T] {
+	n := l.head
+	for ; i > 0; i-- {
+		n = n.next
+	}
+	return n
+}
+
+func (l *List[T]) checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("list: index %d out of range [0:%d]", i, n))
+	}
+}
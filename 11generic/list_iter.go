@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package main
+
+import "iter"
+
+// Values returns an iterator over the elements of l in order.
+func (l *List[T]) Values() iter.Seq[T] {
+	return l.All()
+}
+
+// Enumerate returns an iterator over the indexes and elements of l,
+// like ranging over a slice.
+func (l *List[T]) Enumerate() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for n := l.head; n != nil; n = n.next {
+			if !yield(i, n.val) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Collect returns a list holding the values of seq in order.
+func Collect[T any](seq iter.Seq[T]) *List[T] {
+	l := &List[T]{}
+	for v := range seq {
+		l.PushBack(v)
+	}
+	return l
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListIterators(t *testing.T) {
+	f := func(s []int) bool {
+		l := Collect(slices.Values(s))
+		if !slices.Equal(slices.Collect(l.Values()), s) {
+			return false
+		}
+		for i, v := range l.Enumerate() {
+			if v != s[i] {
+				return false
+			}
+		}
+		return true
+	}
+	quickCheck(t, f)
+
+	l := FromSlice([]string{"a", "b", "c"})
+	var got []string
+	for i, v := range l.Enumerate() {
+		if i == 2 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("Enumerate with break = %v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListSliceRoundTrip(t *testing.T) {
+	f := func(s []int) bool {
+		l := FromSlice(s)
+		return l.Len() == len(s) && slices.Equal(l.Slice(), s)
+	}
+	quickCheck(t, f)
+}
+
+func TestListReverse(t *testing.T) {
+	f := func(s []string) bool {
+		l := FromSlice(s)
+		l.Reverse()
+		want := slices.Clone(s)
+		slices.Reverse(want)
+		if !slices.Equal(l.Slice(), want) {
+			return false
+		}
+
+		// the tail must follow the reversal so PushBack still appends
+		l.PushBack("end")
+		l.Reverse()
+		return slices.Equal(l.Slice(), append([]string{"end"}, s...))
+	}
+	quickCheck(t, f)
+}
+
+// TestListModel applies a random sequence of operations to a List and to
+// a slice and checks after each one that they agree.
+func TestListModel(t *testing.T) {
+	f := func(ops []uint8, vals []int16) bool {
+		var l List[int16]
+		var model []int16
+
+		for i, op := range ops {
+			v := int16(i)
+			if i < len(vals) {
+				v = vals[i]
+			}
+			pos := int(op>>3) % (len(model) + 1)
+
+			switch op % 8 {
+			case 0:
+				l.PushFront(v)
+				model = slices.Insert(model, 0, v)
+			case 1:
+				l.PushBack(v)
+				model = append(model, v)
+			case 2:
+				got, ok := l.PopFront()
+				if ok != (len(model) > 0) || ok && got != model[0] {
+					return false
+				}
+				if ok {
+					model = model[1:]
+				}
+			case 3:
+				got, ok := l.PopBack()
+				if ok != (len(model) > 0) || ok && got != model[len(model)-1] {
+					return false
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			case 4:
+				l.Insert(pos, v)
+				model = slices.Insert(model, pos, v)
+			case 5:
+				if pos < len(model) {
+					if l.Remove(pos) != model[pos] {
+						return false
+					}
+					model = slices.Delete(model, pos, pos+1)
+				}
+			case 6:
+				l.Reverse()
+				slices.Reverse(model)
+			case 7:
+				if pos < len(model) && l.At(pos) != model[pos] {
+					return false
+				}
+			}
+
+			front, okf := l.Front()
+			back, okb := l.Back()
+			if l.Len() != len(model) || !slices.Equal(l.Slice(), model) || !sameEnds(model, front, okf, back, okb) {
+				return false
+			}
+		}
+		return true
+	}
+	quickCheck(t, f)
+}
+
+func TestListAllStopsEarly(t *testing.T) {
+	l := FromSlice([]int{1, 2, 3, 4})
+	var got []int
+	l.All()(func(v int) bool {
+		got = append(got, v)
+		return v < 2
+	})
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("All visited %v, want [1 2]", got)
+	}
+}
+
+func TestListZeroValue(t *testing.T) {
+	var l List[string]
+	if _, ok := l.PopFront(); ok {
+		t.Fatal("PopFront on an empty list reported ok")
+	}
+	if _, ok := l.PopBack(); ok {
+		t.Fatal("PopBack on an empty list reported ok")
+	}
+	l.Insert(0, "a")
+	if l.String() != "[a]" || l.Len() != 1 {
+		t.Fatalf("list = %v", &l)
+	}
+}
+
+func TestListIndexPanics(t *testing.T) {
+	l := FromSlice([]int{1, 2})
+	mustPanic(t, map[string]func(){
+		"At(2)":      func() { l.At(2) },
+		"At(-1)":     func() { l.At(-1) },
+		"Insert(3)":  func() { l.Insert(3, 0) },
+		"Remove(2)":  func() { l.Remove(2) },
+		"Remove(-1)": func() { l.Remove(-1) },
+	})
+}
@@ -9,6 +9,8 @@ TYPE PARAMETERS
 */
 
 // Index returns the index of givenElem in the givenSlice, or -1 if not found.
+// The collections module (16collections) has more functions in this style,
+// and its set.Set answers repeated membership tests without a linear search.
 func Index[T comparable](
 	givenSlice []T, givenElem T) int {
 
@@ -32,15 +34,40 @@ func TypeParamExample() {
 	fmt.Println(Index(ss, "hello"))
 }
 
-/* GENERIC TYPES */
+/*
+GENERIC TYPES
+- a type can be parameterized with a type parameter, which could be useful
+    for implementing generic data structures
+- List[T] in list.go is a singly linked list holding any type of value; the
+    tour's `struct { next *List[T]; val T }` became the unexported node[T],
+    and List[T] wraps the nodes to keep track of the tail and the length
+- DList[T] (dlist.go) links both ways, so an *Element[T] handle can be removed
+    or moved in O(1), and Deque[T] (deque.go) is a ring buffer with O(1)
+    push/pop at both ends and indexing
+*/
 
-type List[T any] struct {
-	next *List[T]
-	val  T
-}
+func GenericTypeExample() {
+	l := FromSlice([]int{1, 2, 3})
+	l.PushFront(0)
+	l.PushBack(4)
+	l.Insert(2, 99)
+	fmt.Println(l, l.Len()) // [0 1 99 2 3 4] 6
 
-// TODO: add functionality later
+	l.Remove(2)
+	l.Reverse()
+	// with Go 1.23 this is `for v := range l.All()`
+	l.All()(func(v int) bool {
+		fmt.Print(v, " ")
+		return true
+	})
+	fmt.Println()
+
+	words := FromSlice([]string{"foo", "bar"})
+	first, _ := words.PopFront()
+	fmt.Println(first, words.Slice())
+}
 
 func main() {
 	TypeParamExample()
+	// GenericTypeExample()
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"example.com/numeric/stats"
 )
 
 /*
@@ -83,6 +85,37 @@ func ChannelExample() {
 	fmt.Println(x, y, x+y)
 }
 
+/*
+# MORE THAN A SUM
+- sum only totals ints; stats.Accumulator keeps count, mean, variance, min and max
+    of any number type in one pass (Welford's algorithm)
+- each goroutine gets its own accumulator (no locks needed) and sends it over the
+    channel when done, the receiver merges them into the overall result
+*/
+
+func accumulate(s []float64, c chan stats.Accumulator) {
+	c <- stats.Accumulate(s)
+}
+
+func statsExample() {
+	MySlice := []float64{7, 2, 8, -9, 4, 0, 3.5, 1}
+
+	c := make(chan stats.Accumulator)
+	mid := len(MySlice) / 2
+	go accumulate(MySlice[:mid], c)
+	go accumulate(MySlice[mid:], c)
+
+	var total stats.Accumulator
+	for i := 0; i < 2; i++ {
+		total.Merge(<-c)
+	}
+
+	mean, _ := total.Mean()
+	sd, _ := total.StdDev()
+	median, _ := stats.Median(MySlice)
+	fmt.Printf("n=%d mean=%g stddev=%.4f median=%g\n", total.Count(), mean, sd, median)
+}
+
 /*
 # Buffered Channel
 - provide the buffer length as the second argument to make to initialize a buffered channel
@@ -283,6 +316,7 @@ func main() {
 	// say("nice") //only executed after completing prev function
 
 	// ChannelExample()
+	// statsExample()
 	// bufferedChannel()
 	// rangeAndClose()
 	// selectExample()
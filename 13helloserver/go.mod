@@ -0,0 +1,3 @@
+module example/helloserver
+
+go 1.21.3
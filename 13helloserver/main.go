@@ -0,0 +1,19 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+)
+
+func main() {
+	//same logger setup as the hello command: prefix and no timestamps
+	log.SetPrefix("helloserver: ")
+	log.SetFlags(0)
+
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, newServer()))
+}
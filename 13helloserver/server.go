@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"example.com/greetings"
+)
+
+const (
+	textPlain = "text/plain"
+	appJSON   = "application/json"
+)
+
+// maxBodyBytes caps the size of a POST /hellos request body.
+const maxBodyBytes = 1 << 20
+
+type helloResponse struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+type hellosRequest struct {
+	Locale string   `json:"locale"`
+	Names  []string `json:"names"`
+}
+
+type hellosResponse struct {
+	Messages  map[string]string   `json:"messages"`
+	Greetings greetings.Greetings `json:"greetings"` // in request order
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// newServer returns the handler serving GET /hello and POST /hellos.
+func newServer() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", handleHello)
+	mux.HandleFunc("/hellos", handleHellos)
+	return mux
+}
+
+func handleHello(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	ctype, ok := negotiate(r.Header.Get("Accept"), textPlain)
+	if !ok {
+		writeError(w, r, http.StatusNotAcceptable, errors.New("no acceptable content type"))
+		return
+	}
+
+	q := r.URL.Query()
+	locale := q.Get("locale")
+	if locale == "" {
+		locale = greetings.DefaultLocale
+	}
+	// a batch of one, for the normalized name that HelloIn does not return
+	gs, err := greetings.HellosOrderedIn(locale, []string{q.Get("name")})
+	if err != nil {
+		var ie *greetings.IndexError
+		if errors.As(err, &ie) {
+			err = ie.Err
+		}
+		writeError(w, r, statusFor(err), err)
+		return
+	}
+
+	if ctype == appJSON {
+		writeJSON(w, http.StatusOK, helloResponse{Name: gs[0].Name, Message: gs[0].Message})
+		return
+	}
+	writeText(w, http.StatusOK, gs[0].Message+"\n")
+}
+
+func handleHellos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeError(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	ctype, ok := negotiate(r.Header.Get("Accept"), appJSON)
+	if !ok {
+		writeError(w, r, http.StatusNotAcceptable, errors.New("no acceptable content type"))
+		return
+	}
+
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != appJSON {
+		writeError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("request body must be %s", appJSON))
+		return
+	}
+
+	req := hellosRequest{Locale: greetings.DefaultLocale} // when the body has none
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+		return
+	}
+
+	gs, err := greetings.HellosOrderedIn(req.Locale, req.Names)
+	if err != nil {
+		writeError(w, r, statusFor(err), err)
+		return
+	}
+
+	if ctype == appJSON {
+		writeJSON(w, http.StatusOK, hellosResponse{Messages: gs.Map(), Greetings: gs})
+		return
+	}
+
+	text, err := gs.MarshalText()
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	writeText(w, http.StatusOK, string(text))
+}
+
+// statusFor maps errors from the greetings package to HTTP statuses:
+// bad names and locales are the client's fault, anything else is ours.
+func statusFor(err error) int {
+	var unknown *greetings.UnknownLocaleError
+	var invalid *greetings.InvalidLocaleError
+
+	switch {
+	case errors.Is(err, greetings.ErrEmptyName),
+		errors.Is(err, greetings.ErrInvalidUTF8),
+		errors.Is(err, greetings.ErrNameTooLong),
+		errors.Is(err, greetings.ErrDisallowedRune),
+		errors.As(err, &unknown),
+		errors.As(err, &invalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// negotiate picks text/plain or application/json from an Accept header,
+// honouring q-values. An empty header or */* yields def.
+func negotiate(accept, def string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return def, true
+	}
+
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		var ctype string
+		switch mt {
+		case textPlain, appJSON:
+			ctype = mt
+		case "*/*":
+			ctype = def
+		case "text/*":
+			ctype = textPlain
+		case "application/*":
+			ctype = appJSON
+		default:
+			continue
+		}
+
+		if q > bestQ {
+			best, bestQ = ctype, q
+		}
+	}
+
+	return best, best != ""
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", appJSON)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+func writeText(w http.ResponseWriter, status int, s string) {
+	w.Header().Set("Content-Type", textPlain+"; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := w.Write([]byte(s)); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+// writeError reports err in JSON if the client prefers it, else as text.
+func writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if ctype, _ := negotiate(r.Header.Get("Accept"), textPlain); ctype == appJSON {
+		writeJSON(w, status, errorResponse{Error: err.Error()})
+		return
+	}
+	writeText(w, status, err.Error()+"\n")
+}
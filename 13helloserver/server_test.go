@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHelloText(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newServer().ServeHTTP(rec, httptest.NewRequest("GET", "/hello?name=Gladys", nil))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Gladys") {
+		t.Fatalf("GET /hello?name=Gladys = %d %q, want 200 and a greeting", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Fatalf("Content-Type = %q, want text/plain", ct)
+	}
+}
+
+func TestHelloJSON(t *testing.T) {
+	req := httptest.NewRequest("GET", "/hello?name=Gladys", nil)
+	req.Header.Set("Accept", "text/plain;q=0.5, application/json")
+	rec := httptest.NewRecorder()
+	newServer().ServeHTTP(rec, req)
+
+	var resp helloResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("GET /hello = %d %q, want 200 and JSON: %v", rec.Code, rec.Body, err)
+	}
+	if resp.Name != "Gladys" || !strings.Contains(resp.Message, "Gladys") {
+		t.Fatalf("GET /hello = %+v, want a greeting for Gladys", resp)
+	}
+}
+
+func TestHelloJSONNormalizedName(t *testing.T) {
+	req := httptest.NewRequest("GET", "/hello?name=%20%20Gladys%20", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	newServer().ServeHTTP(rec, req)
+
+	var resp helloResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Name != "Gladys" {
+		t.Fatalf("GET /hello?name=%%20%%20Gladys%%20 = %d %q, want name \"Gladys\": %v", rec.Code, rec.Body, err)
+	}
+}
+
+func TestHelloErrors(t *testing.T) {
+	tests := []struct {
+		method, target, accept string
+		want                   int
+	}{
+		{"GET", "/hello", "", http.StatusBadRequest},
+		{"GET", "/hello?name=%20%20", "", http.StatusBadRequest},
+		{"GET", "/hello?name=Gladys&locale=xx", "", http.StatusBadRequest},
+		{"GET", "/hello?name=Gladys&locale=not%20a%20tag", "", http.StatusBadRequest},
+		{"GET", "/hello?name=Gladys", "image/png", http.StatusNotAcceptable},
+		{"POST", "/hello?name=Gladys", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, nil)
+		req.Header.Set("Accept", tt.accept)
+		rec := httptest.NewRecorder()
+		newServer().ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Fatalf("%s %s = %d %q, want %d", tt.method, tt.target, rec.Code, rec.Body, tt.want)
+		}
+	}
+}
+
+func TestHellos(t *testing.T) {
+	body := `{"names": ["Gladys", "Samantha"]}`
+	req := httptest.NewRequest("POST", "/hellos", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	newServer().ServeHTTP(rec, req)
+
+	var resp hellosResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("POST /hellos = %d %q, want 200 and JSON: %v", rec.Code, rec.Body, err)
+	}
+	if len(resp.Messages) != 2 || len(resp.Greetings) != 2 || resp.Greetings[1].Name != "Samantha" {
+		t.Fatalf("POST /hellos = %+v, want 2 greetings in request order", resp)
+	}
+}
+
+func TestHellosErrors(t *testing.T) {
+	tests := []struct {
+		body, ctype string
+		want        int
+	}{
+		{`{"names": ["Gladys", ""]}`, "application/json", http.StatusBadRequest},
+		{`{"names": ["Gladys"], "locale": "xx"}`, "application/json", http.StatusBadRequest},
+		{`{"names": [`, "application/json", http.StatusBadRequest},
+		{`{"names": ["Gladys"]}`, "text/plain", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/hellos", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.ctype)
+		rec := httptest.NewRecorder()
+		newServer().ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Fatalf("POST /hellos %#q = %d %q, want %d", tt.body, rec.Code, rec.Body, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package numeric
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// parallelMin is the smallest slice the parallel routines split across
+// goroutines; below it starting them costs more than it saves.
+const parallelMin = 1 << 15
+
+// SqrtTo stores the square root of each src[i] in dst[i] and returns
+// dst. dst may be src itself to work in place. It panics if the lengths
+// differ. Special cases are those of math.Sqrt.
+func SqrtTo(dst, src []float64) []float64 {
+	if len(dst) != len(src) {
+		panic("numeric: SqrtTo slices of different lengths")
+	}
+
+	// unrolled by four so the compiler can keep several sqrt
+	// instructions in flight and drop most bounds checks
+	i := 0
+	for ; i+4 <= len(src); i += 4 {
+		s := src[i : i+4 : i+4]
+		d := dst[i : i+4 : i+4]
+		d[0] = math.Sqrt(s[0])
+		d[1] = math.Sqrt(s[1])
+		d[2] = math.Sqrt(s[2])
+		d[3] = math.Sqrt(s[3])
+	}
+	for ; i < len(src); i++ {
+		dst[i] = math.Sqrt(src[i])
+	}
+
+	return dst
+}
+
+// HypotTo stores the distance from the origin of each point (xs[i], ys[i])
+// in dst[i] and returns dst, e.g. for the lengths of many Vertex values.
+// It panics if the lengths differ.
+func HypotTo(dst, xs, ys []float64) []float64 {
+	if len(dst) != len(xs) || len(xs) != len(ys) {
+		panic("numeric: HypotTo slices of different lengths")
+	}
+
+	for i := range dst {
+		dst[i] = math.Hypot(xs[i], ys[i])
+	}
+
+	return dst
+}
+
+// SqrtToParallel is SqrtTo split across up to workers goroutines
+// (GOMAXPROCS when workers <= 0). Small slices are done on the calling
+// goroutine.
+func SqrtToParallel(dst, src []float64, workers int) []float64 {
+	if len(dst) != len(src) {
+		panic("numeric: SqrtToParallel slices of different lengths")
+	}
+
+	parallel(len(src), workers, func(lo, hi int) {
+		SqrtTo(dst[lo:hi], src[lo:hi])
+	})
+
+	return dst
+}
+
+// HypotToParallel is HypotTo split across up to workers goroutines.
+func HypotToParallel(dst, xs, ys []float64, workers int) []float64 {
+	if len(dst) != len(xs) || len(xs) != len(ys) {
+		panic("numeric: HypotToParallel slices of different lengths")
+	}
+
+	parallel(len(dst), workers, func(lo, hi int) {
+		HypotTo(dst[lo:hi], xs[lo:hi], ys[lo:hi])
+	})
+
+	return dst
+}
+
+// parallel calls fn on consecutive chunks of [0, n), one goroutine per
+// chunk, and waits for them all.
+func parallel(n, workers int, fn func(lo, hi int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n/parallelMin)
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	chunk := (n + workers - 1) / workers
+	for lo := 0; lo < n; lo += chunk {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, min(lo+chunk, n))
+	}
+	wg.Wait()
+}
@@ -0,0 +1,99 @@
+package numeric
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomSlice(n int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = rand.ExpFloat64() * 1e6
+	}
+	return s
+}
+
+func TestSqrtTo(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 4, 7, 1000, parallelMin*3 + 5} {
+		src := randomSlice(n)
+		if n > 3 {
+			src[1], src[2], src[3] = -1, math.Inf(1), math.NaN()
+		}
+
+		serial := SqrtTo(make([]float64, n), src)
+		par := SqrtToParallel(make([]float64, n), src, 4)
+		for i, x := range src {
+			want := math.Sqrt(x)
+			if !same(serial[i], want) || !same(par[i], want) {
+				t.Fatalf("n=%d: sqrt(%g) = %g (serial), %g (parallel), want %g", n, x, serial[i], par[i], want)
+			}
+		}
+
+		// in place
+		SqrtToParallel(src, src, 0)
+		for i := range src {
+			if !same(src[i], serial[i]) {
+				t.Fatalf("n=%d: in place [%d] = %g, want %g", n, i, src[i], serial[i])
+			}
+		}
+	}
+}
+
+func TestHypotTo(t *testing.T) {
+	n := parallelMin*2 + 1
+	xs, ys := randomSlice(n), randomSlice(n)
+	xs[0], ys[0] = 3, 4
+
+	got := HypotToParallel(make([]float64, n), xs, ys, 0)
+	if got[0] != 5 {
+		t.Fatalf("HypotTo(3, 4) = %g, want 5", got[0])
+	}
+	for i := range got {
+		if want := math.Hypot(xs[i], ys[i]); got[i] != want {
+			t.Fatalf("HypotTo[%d] = %g, want %g", i, got[i], want)
+		}
+	}
+}
+
+func TestSqrtToLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("SqrtTo with different lengths did not panic")
+		}
+	}()
+	SqrtTo(make([]float64, 2), make([]float64, 3))
+}
+
+// benchN is the length of the slices the benchmarks work on; each
+// benchmark fills its own so plain test runs don't pay for 8 MB.
+const benchN = 1 << 20
+
+func BenchmarkNaiveLoop(b *testing.B) {
+	src, dst := randomSlice(benchN), make([]float64, benchN)
+	b.SetBytes(8 * benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, x := range src {
+			dst[j] = math.Sqrt(x)
+		}
+	}
+}
+
+func BenchmarkSqrtTo(b *testing.B) {
+	src, dst := randomSlice(benchN), make([]float64, benchN)
+	b.SetBytes(8 * benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SqrtTo(dst, src)
+	}
+}
+
+func BenchmarkSqrtToParallel(b *testing.B) {
+	src, dst := randomSlice(benchN), make([]float64, benchN)
+	b.SetBytes(8 * benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SqrtToParallel(dst, src, 0)
+	}
+}
@@ -0,0 +1,127 @@
+// Package bignum computes square roots of math/big numbers to a
+// requested number of decimal digits.
+package bignum
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"example.com/numeric"
+)
+
+var (
+	// ErrNegative is the cause of the numeric.ErrDomain error returned
+	// for square roots of negative numbers.
+	ErrNegative = errors.New("square root of negative number")
+
+	// ErrDigits is returned when the requested number of digits is not positive.
+	ErrDigits = errors.New("number of digits must be positive")
+)
+
+// guardBits are carried through the iterations on top of the requested
+// precision, so the final rounding is not affected by accumulated error.
+const guardBits = 32
+
+// log2of10 converts a count of decimal digits to bits.
+const log2of10 = 3.3219280948873626
+
+// Prec returns the number of mantissa bits needed for digits significant
+// decimal digits.
+func Prec(digits int) uint {
+	return uint(math.Ceil(float64(digits) * log2of10))
+}
+
+// SqrtFloat returns the square root of x rounded to Prec(digits) bits.
+//
+// It uses Newton's method, z = (z + x/z) / 2, from a float64 estimate.
+// Each iteration doubles the number of correct bits, so each one is done
+// at twice the precision of the one before instead of at full precision.
+func SqrtFloat(x *big.Float, digits int) (*big.Float, error) {
+	if digits <= 0 {
+		return nil, ErrDigits
+	}
+	prec := Prec(digits)
+
+	switch {
+	case x.Sign() < 0:
+		in, _ := x.Float64()
+		return nil, numeric.NewError("bignum.SqrtFloat", in, numeric.ErrDomain, ErrNegative, numeric.Result{})
+	case x.Sign() == 0, x.IsInf():
+		// ±0 and +Inf are their own square roots
+		return new(big.Float).SetPrec(prec).Set(x), nil
+	}
+
+	// x = mant × 2^exp with an even exp, so √x = √mant × 2^(exp/2)
+	// and mant in [0.5, 2) fits in a float64 for the first estimate
+	mant := new(big.Float)
+	exp := x.MantExp(mant)
+	if exp%2 != 0 {
+		mant.SetMantExp(mant, 1)
+		exp--
+	}
+
+	m, _ := mant.Float64()
+	z := new(big.Float).SetFloat64(math.Sqrt(m))
+	t := new(big.Float)
+
+	// a float64 square root is good to at least 50 bits
+	for correct := uint(50); ; correct *= 2 {
+		p := min(2*correct, prec) + guardBits
+		z.SetPrec(p)
+		t.SetPrec(p).Quo(mant, z)
+		z.Add(z, t)
+		z.SetMantExp(z, -1)
+
+		if 2*correct >= prec+guardBits {
+			break
+		}
+	}
+
+	z.SetMantExp(z, exp/2)
+	return z.SetPrec(prec), nil
+}
+
+// SqrtRat returns the square root of x to digits significant decimal
+// digits, as the exact rational value of the rounded binary result.
+func SqrtRat(x *big.Rat, digits int) (*big.Rat, error) {
+	if digits <= 0 {
+		return nil, ErrDigits
+	}
+
+	f := new(big.Float).SetPrec(Prec(digits) + guardBits).SetRat(x)
+	z, err := SqrtFloat(f, digits)
+	if err != nil {
+		return nil, err
+	}
+
+	r, _ := z.Rat(nil)
+	return r, nil
+}
+
+// SqrtInt returns the integer square root of n, the largest z with
+// z×z <= n, using Newton's method on integers.
+func SqrtInt(n *big.Int) (*big.Int, error) {
+	switch n.Sign() {
+	case -1:
+		in, _ := new(big.Float).SetInt(n).Float64()
+		return nil, numeric.NewError("bignum.SqrtInt", in, numeric.ErrDomain, ErrNegative, numeric.Result{})
+	case 0:
+		return new(big.Int), nil
+	}
+
+	// start above the root: 2^ceil(bits/2) > √n, so the iteration
+	// decreases monotonically until it reaches the floor of the root
+	z := new(big.Int).Lsh(big.NewInt(1), uint(n.BitLen()+1)/2)
+	y := new(big.Int)
+	for {
+		y.Quo(n, z)
+		y.Add(y, z)
+		y.Rsh(y, 1)
+
+		if y.Cmp(z) >= 0 {
+			return z, nil
+		}
+		z.Set(y)
+	}
+}
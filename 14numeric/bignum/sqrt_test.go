@@ -0,0 +1,149 @@
+package bignum
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"example.com/numeric"
+)
+
+func TestSqrtFloat(t *testing.T) {
+	inputs := []string{"2", "0.5", "36", "1e-1000", "123456789.987654321", "1e1001", "0.0001"}
+
+	for _, digits := range []int{1, 15, 50, 300, 2000} {
+		for _, in := range inputs {
+			prec := Prec(digits)
+			x, _, err := big.ParseFloat(in, 10, prec+guardBits, big.ToNearestEven)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := SqrtFloat(x, digits)
+			if err != nil {
+				t.Fatalf("SqrtFloat(%s, %d) error = %v", in, digits, err)
+			}
+			// big.Float.Sqrt can be off in the last dozen or so digits,
+			// e.g. for 1e-1000 at 2000 digits, so the reference is
+			// computed at twice the precision and then rounded
+			want := new(big.Float).SetPrec(2 * prec).Sqrt(x)
+			want.SetPrec(prec)
+
+			if got.Prec() != prec {
+				t.Fatalf("SqrtFloat(%s, %d) has precision %d, want %d", in, digits, got.Prec(), prec)
+			}
+			if !withinULP(got, want) {
+				t.Fatalf("SqrtFloat(%s, %d) = %s, want %s", in, digits, got.Text('g', digits), want.Text('g', digits))
+			}
+		}
+	}
+}
+
+func TestSqrtFloatSpecial(t *testing.T) {
+	if _, err := SqrtFloat(big.NewFloat(-1), 10); !errors.Is(err, ErrNegative) || !errors.Is(err, numeric.ErrDomain) {
+		t.Fatalf("SqrtFloat(-1) error = %v, want ErrNegative domain error", err)
+	}
+	if _, err := SqrtFloat(big.NewFloat(2), 0); !errors.Is(err, ErrDigits) {
+		t.Fatalf("SqrtFloat(2, 0) error = %v, want ErrDigits", err)
+	}
+
+	z, err := SqrtFloat(new(big.Float).SetInf(false), 10)
+	if err != nil || !z.IsInf() {
+		t.Fatalf("SqrtFloat(+Inf) = %v, %v, want +Inf", z, err)
+	}
+	z, err = SqrtFloat(new(big.Float), 10)
+	if err != nil || z.Sign() != 0 {
+		t.Fatalf("SqrtFloat(0) = %v, %v, want 0", z, err)
+	}
+}
+
+func TestSqrtRat(t *testing.T) {
+	x := big.NewRat(9, 4)
+	got, err := SqrtRat(x, 30)
+	if err != nil || got.Cmp(big.NewRat(3, 2)) != 0 {
+		t.Fatalf("SqrtRat(9/4) = %v, %v, want 3/2", got, err)
+	}
+
+	x = big.NewRat(2, 1)
+	got, err = SqrtRat(x, 40)
+	if err != nil {
+		t.Fatalf("SqrtRat(2) error = %v", err)
+	}
+	want, _ := new(big.Rat).SetString("1.4142135623730950488016887242096980785696718753769")
+	tol, _ := new(big.Rat).SetString("1e-39")
+	diff := new(big.Rat).Sub(got, want)
+	if diff.Abs(diff).Cmp(tol) > 0 {
+		t.Fatalf("SqrtRat(2, 40) = %s, want %s", got.FloatString(45), want.FloatString(45))
+	}
+}
+
+func TestSqrtInt(t *testing.T) {
+	inputs := []string{"0", "1", "2", "3", "4", "15", "16", "17", "99999999999999999999",
+		"340282366920938463463374607431768211456", "340282366920938463463374607431768211455"}
+
+	for _, in := range inputs {
+		n, _ := new(big.Int).SetString(in, 10)
+		got, err := SqrtInt(n)
+		want := new(big.Int).Sqrt(n)
+		if err != nil || got.Cmp(want) != 0 {
+			t.Fatalf("SqrtInt(%s) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+
+	if _, err := SqrtInt(big.NewInt(-4)); !errors.Is(err, ErrNegative) {
+		t.Fatalf("SqrtInt(-4) error = %v, want ErrNegative", err)
+	}
+}
+
+// withinULP reports whether got and want differ by at most one unit in
+// the last place of want. Rounding the guard bits away can land on
+// either neighbour of a value that is almost exactly half way.
+func withinULP(got, want *big.Float) bool {
+	diff := new(big.Float).Sub(got, want)
+	if diff.Sign() == 0 {
+		return true
+	}
+
+	ulp := new(big.Float).SetMantExp(big.NewFloat(1), want.MantExp(nil)-int(want.Prec()))
+	return diff.Abs(diff).Cmp(ulp) <= 0
+}
+
+func benchmarkInput(digits int) *big.Float {
+	x, _, _ := big.ParseFloat("2", 10, Prec(digits), big.ToNearestEven)
+	return x
+}
+
+func BenchmarkSqrtFloat1000(b *testing.B) {
+	x := benchmarkInput(1000)
+	for i := 0; i < b.N; i++ {
+		SqrtFloat(x, 1000)
+	}
+}
+
+func BenchmarkBigFloatSqrt1000(b *testing.B) {
+	x := benchmarkInput(1000)
+	for i := 0; i < b.N; i++ {
+		new(big.Float).SetPrec(Prec(1000)).Sqrt(x)
+	}
+}
+
+func BenchmarkSqrtFloat10000(b *testing.B) {
+	x := benchmarkInput(10000)
+	for i := 0; i < b.N; i++ {
+		SqrtFloat(x, 10000)
+	}
+}
+
+func BenchmarkBigFloatSqrt10000(b *testing.B) {
+	x := benchmarkInput(10000)
+	for i := 0; i < b.N; i++ {
+		new(big.Float).SetPrec(Prec(10000)).Sqrt(x)
+	}
+}
+
+func BenchmarkSqrtInt(b *testing.B) {
+	n := new(big.Int).Lsh(big.NewInt(3), 4000)
+	for i := 0; i < b.N; i++ {
+		SqrtInt(n)
+	}
+}
@@ -0,0 +1,365 @@
+// Package decimal implements arbitrary-precision decimal numbers for
+// money and other values that must not pick up binary rounding errors.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"example.com/numeric"
+	"example.com/numeric/bignum"
+)
+
+var (
+	// ErrDivisionByZero is returned by Quo for a zero divisor.
+	ErrDivisionByZero = errors.New("decimal division by zero")
+
+	// ErrScaleRange is returned by Quo and Sqrt for a scale beyond
+	// MaxScale in either direction.
+	ErrScaleRange = errors.New("decimal scale out of range")
+)
+
+// MaxScale bounds the scale of every Decimal in either direction. Without
+// it "1e2000000000" would parse, and then formatting or aligning it with
+// another value would need gigabytes of digits. Parse, Quo and Sqrt
+// return an error for scales beyond it; New, NewFromBigInt, Mul, Round
+// and Rescale panic, as a scale that large is a programming error.
+const MaxScale = 10000
+
+// Decimal is the exact value coef × 10^-scale. The zero value is 0.
+// Decimals are immutable: every operation returns a new value.
+type Decimal struct {
+	coef  *big.Int // nil means 0
+	scale int32    // digits after the decimal point; negative for trailing zeros
+}
+
+// Decimal values have an absolute value like MyFloat.
+var _ numeric.Abser = Decimal{}
+
+// New returns coef × 10^-scale, e.g. New(1999, 2) is 19.99.
+func New(coef int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), scale: mustScale(int64(scale))}
+}
+
+// NewFromBigInt returns coef × 10^-scale. coef is copied.
+func NewFromBigInt(coef *big.Int, scale int32) Decimal {
+	return Decimal{coef: new(big.Int).Set(coef), scale: mustScale(int64(scale))}
+}
+
+// Parse parses a decimal such as "-12.50", "+3" or "1.5e-3".
+// The scale of the result is the number of digits given after the
+// point, adjusted by the exponent, so "12.50" has scale 2.
+func Parse(s string) (Decimal, error) {
+	mant, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mant = s[:i]
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("decimal: bad exponent in %q", s)
+		}
+	}
+
+	neg := false
+	if mant != "" && (mant[0] == '+' || mant[0] == '-') {
+		neg = mant[0] == '-'
+		mant = mant[1:]
+	}
+
+	whole, frac, _ := strings.Cut(mant, ".")
+	digits := whole + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", s)
+	}
+
+	scale := int64(len(frac)) - exp
+	if !validScale(scale) {
+		return Decimal{}, fmt.Errorf("decimal: exponent out of range in %q", s)
+	}
+
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Coef returns a copy of the coefficient of d.
+func (d Decimal) Coef() *big.Int {
+	return new(big.Int).Set(d.bigCoef())
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 for d < 0, d == 0 or d > 0.
+func (d Decimal) Sign() int {
+	return d.bigCoef().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or +1 for d < e, d == e or d > e. Values that are
+// equal but have different scales, like 1.5 and 1.50, compare equal.
+func (d Decimal) Cmp(e Decimal) int {
+	x, y := align(d, e)
+	return x.Cmp(y)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.bigCoef()), scale: d.scale}
+}
+
+// Abs returns |d| as a float64, so Decimal is an Abser like MyFloat.
+// Use AbsDecimal for the exact value.
+func (d Decimal) Abs() float64 {
+	return d.AbsDecimal().Float64()
+}
+
+// AbsDecimal returns |d|.
+func (d Decimal) AbsDecimal() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.bigCoef()), scale: d.scale}
+}
+
+// Square returns d² as a float64, like MyFloat.Square.
+// Use SquareDecimal for the exact value.
+func (d Decimal) Square() float64 {
+	return d.SquareDecimal().Float64()
+}
+
+// SquareDecimal returns d², with twice the scale of d.
+func (d Decimal) SquareDecimal() Decimal {
+	return d.Mul(d)
+}
+
+// Add returns d + e, with the larger of their scales.
+func (d Decimal) Add(e Decimal) Decimal {
+	x, y := align(d, e)
+	return Decimal{coef: x.Add(x, y), scale: max(d.scale, e.scale)}
+}
+
+// Sub returns d - e, with the larger of their scales.
+func (d Decimal) Sub(e Decimal) Decimal {
+	x, y := align(d, e)
+	return Decimal{coef: x.Sub(x, y), scale: max(d.scale, e.scale)}
+}
+
+// Mul returns d × e exactly; its scale is the sum of theirs.
+func (d Decimal) Mul(e Decimal) Decimal {
+	scale := mustScale(int64(d.scale) + int64(e.scale))
+	return Decimal{coef: new(big.Int).Mul(d.bigCoef(), e.bigCoef()), scale: scale}
+}
+
+// Quo returns d / e rounded to scale digits after the point with mode.
+func (d Decimal) Quo(e Decimal, scale int32, mode RoundingMode) (Decimal, error) {
+	if e.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	if !validScale(int64(scale)) {
+		return Decimal{}, ErrScaleRange
+	}
+
+	// d/e = (dc / ec) × 10^(es - ds), wanted as q × 10^-scale
+	num := new(big.Int).Set(d.bigCoef())
+	den := new(big.Int).Set(e.bigCoef())
+	if k := int64(scale) + int64(e.scale) - int64(d.scale); k >= 0 {
+		num.Mul(num, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+
+	return Decimal{coef: roundQuo(num, den, mode), scale: scale}, nil
+}
+
+// Round returns d rounded to scale digits after the point with mode.
+// Rounding to a larger scale than d has only appends zeros.
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return d.Rescale(scale)
+	}
+	return Decimal{coef: roundQuo(d.bigCoef(), pow10(int64(d.scale)-int64(scale)), mode), scale: mustScale(int64(scale))}
+}
+
+// Rescale returns d with scale digits after the point, which must be at
+// least d.Scale() so that no digits are lost; otherwise it panics.
+func (d Decimal) Rescale(scale int32) Decimal {
+	if scale < d.scale {
+		panic("decimal: Rescale would lose digits, use Round")
+	}
+	mustScale(int64(scale))
+	coef := new(big.Int).Mul(d.bigCoef(), pow10(int64(scale)-int64(d.scale)))
+	return Decimal{coef: coef, scale: scale}
+}
+
+// Sqrt returns the square root of d rounded to scale digits after the
+// point with mode. The root is computed exactly with an integer square
+// root before rounding, so every mode rounds correctly.
+func (d Decimal) Sqrt(scale int32, mode RoundingMode) (Decimal, error) {
+	if d.Sign() < 0 {
+		return Decimal{}, numeric.NewError("decimal.Sqrt", d.Float64(), numeric.ErrDomain, nil, numeric.Result{})
+	}
+	if !validScale(int64(scale)) {
+		return Decimal{}, ErrScaleRange
+	}
+
+	// √(c × 10^-s) = √(c × 10^(2×scale - s)) × 10^-scale, and the
+	// radicand is the fraction n/den so nothing is lost when 2×scale < s
+	n := new(big.Int).Set(d.bigCoef())
+	den := big.NewInt(1)
+	if k := 2*int64(scale) - int64(d.scale); k >= 0 {
+		n.Mul(n, pow10(k))
+	} else {
+		den = pow10(-k)
+	}
+
+	// floor(√(n/den)) = floor(√floor(n/den))
+	r, err := bignum.SqrtInt(new(big.Int).Quo(n, den))
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	// the root is r + f with 0 <= f < 1: exact if r²×den == n, and
+	// f compares to ½ as 4n compares to (2r+1)²×den
+	r2 := new(big.Int).Mul(r, r)
+	if r2.Mul(r2, den).Cmp(n) != 0 {
+		h := new(big.Int).Lsh(r, 1)
+		h.Add(h, big.NewInt(1))
+		h.Mul(h, h).Mul(h, den)
+		half := new(big.Int).Lsh(n, 2).Cmp(h)
+
+		if mode.roundUp(1, half, r.Bit(0) == 1) {
+			r.Add(r, big.NewInt(1))
+		}
+	}
+
+	return Decimal{coef: r, scale: scale}, nil
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Rat returns d as an exact fraction.
+func (d Decimal) Rat() *big.Rat {
+	if d.scale >= 0 {
+		return new(big.Rat).SetFrac(d.bigCoef(), pow10(int64(d.scale)))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Mul(d.bigCoef(), pow10(-int64(d.scale))))
+}
+
+// String formats d in plain decimal notation with exactly Scale()
+// digits after the point, e.g. "-12.50".
+func (d Decimal) String() string {
+	coef := d.bigCoef()
+	if d.scale <= 0 {
+		s := coef.String()
+		if coef.Sign() != 0 {
+			s += strings.Repeat("0", int(-d.scale))
+		}
+		return s
+	}
+
+	digits := new(big.Int).Abs(coef).String()
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	sign := ""
+	if coef.Sign() < 0 {
+		sign = "-"
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalText formats d like String.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses text like Parse.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON number with all its digits, so no
+// precision is lost to float64 on the way.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one.
+// null leaves d unchanged.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+func (d Decimal) bigCoef() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// validScale reports whether scale is within MaxScale.
+func validScale(scale int64) bool {
+	return -MaxScale <= scale && scale <= MaxScale
+}
+
+// mustScale returns scale as an int32, or panics if it is beyond MaxScale.
+func mustScale(scale int64) int32 {
+	if !validScale(scale) {
+		panic(fmt.Sprintf("decimal: scale %d out of range, limit is %d", scale, MaxScale))
+	}
+	return int32(scale)
+}
+
+// align returns new copies of the coefficients of d and e scaled to the
+// larger of their scales.
+func align(d, e Decimal) (*big.Int, *big.Int) {
+	x := new(big.Int).Set(d.bigCoef())
+	y := new(big.Int).Set(e.bigCoef())
+	switch {
+	case d.scale < e.scale:
+		x.Mul(x, pow10(int64(e.scale)-int64(d.scale)))
+	case e.scale < d.scale:
+		y.Mul(y, pow10(int64(d.scale)-int64(e.scale)))
+	}
+	return x, y
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
@@ -0,0 +1,318 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"example.com/numeric"
+)
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		in, want string
+		scale    int32
+	}{
+		{"0", "0", 0},
+		{"12.50", "12.50", 2},
+		{"-0.05", "-0.05", 2},
+		{"+3", "3", 0},
+		{".5", "0.5", 1},
+		{"7.", "7", 0},
+		{"1.5e-3", "0.0015", 4},
+		{"25E2", "2500", -2},
+		{"1e10000", "1" + strings.Repeat("0", 10000), -MaxScale},
+		{"1e-10000", "0." + strings.Repeat("0", 9999) + "1", MaxScale},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9},
+	}
+
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got := d.String(); got != tt.want || d.Scale() != tt.scale {
+			t.Fatalf("Parse(%q) = %s (scale %d), want %s (scale %d)", tt.in, got, d.Scale(), tt.want, tt.scale)
+		}
+	}
+
+	for _, in := range []string{"", "-", ".", "1.2.3", "1e", "1e+", "1ex", "0x10", " 1", "1,5", "1e99999999999", "1e2000000000", "1e-10001", "1.5e10002"} {
+		if d, err := Parse(in); err == nil {
+			t.Fatalf("Parse(%q) = %s, want error", in, d)
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var z Decimal
+	if z.String() != "0" || !z.IsZero() || z.Sign() != 0 || z.Abs() != 0 {
+		t.Fatalf("zero value = %s, sign %d", z, z.Sign())
+	}
+	if got := z.Add(New(5, 1)); got.String() != "0.5" {
+		t.Fatalf("0 + 0.5 = %s", got)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := MustParse("19.99"), MustParse("0.015")
+
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"Add", a.Add(b), "20.005"},
+		{"Sub", b.Sub(a), "-19.975"},
+		{"Mul", a.Mul(b), "0.29985"},
+		{"Neg", a.Neg(), "-19.99"},
+		{"AbsDecimal", a.Neg().AbsDecimal(), "19.99"},
+		{"SquareDecimal", b.Neg().SquareDecimal(), "0.000225"},
+	}
+
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Fatalf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// the classic binary floating point surprise does not happen
+	sum := MustParse("0.1").Add(MustParse("0.2"))
+	if sum.Cmp(MustParse("0.3")) != 0 {
+		t.Fatalf("0.1 + 0.2 = %s, want 0.3", sum)
+	}
+}
+
+func TestImmutable(t *testing.T) {
+	a := MustParse("1.5")
+	a.Add(a)
+	a.Neg()
+	a.Round(0, ToNearestEven)
+	a.Coef().SetInt64(99)
+	if a.String() != "1.5" {
+		t.Fatalf("a = %s after operations, want 1.5", a)
+	}
+}
+
+func TestCmp(t *testing.T) {
+	if c := MustParse("1.5").Cmp(MustParse("1.500")); c != 0 {
+		t.Fatalf("Cmp(1.5, 1.500) = %d, want 0", c)
+	}
+	if c := MustParse("-2").Cmp(MustParse("-1.99")); c != -1 {
+		t.Fatalf("Cmp(-2, -1.99) = %d, want -1", c)
+	}
+	if c := MustParse("1e3").Cmp(MustParse("999.9")); c != 1 {
+		t.Fatalf("Cmp(1e3, 999.9) = %d, want 1", c)
+	}
+}
+
+func TestRound(t *testing.T) {
+	modes := []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero, ToNegativeInf, ToPositiveInf}
+	tests := []struct {
+		in   string
+		want [6]string
+	}{
+		{"2.5", [6]string{"2", "3", "2", "3", "2", "3"}},
+		{"3.5", [6]string{"4", "4", "3", "4", "3", "4"}},
+		{"-2.5", [6]string{"-2", "-3", "-2", "-3", "-3", "-2"}},
+		{"2.51", [6]string{"3", "3", "2", "3", "2", "3"}},
+		{"-2.49", [6]string{"-2", "-2", "-2", "-3", "-3", "-2"}},
+		{"7", [6]string{"7", "7", "7", "7", "7", "7"}},
+	}
+
+	for _, tt := range tests {
+		for i, mode := range modes {
+			if got := MustParse(tt.in).Round(0, mode); got.String() != tt.want[i] {
+				t.Fatalf("Round(%s, 0, %v) = %s, want %s", tt.in, mode, got, tt.want[i])
+			}
+		}
+	}
+
+	if got := MustParse("1.005").Round(2, ToNearestAway); got.String() != "1.01" {
+		t.Fatalf("Round(1.005, 2) = %s, want 1.01", got)
+	}
+	if got := MustParse("1234").Round(-2, ToNearestEven); got.String() != "1200" {
+		t.Fatalf("Round(1234, -2) = %s, want 1200", got)
+	}
+	if got := MustParse("1.5").Round(3, ToZero); got.String() != "1.500" {
+		t.Fatalf("Round(1.5, 3) = %s, want 1.500", got)
+	}
+}
+
+func TestQuo(t *testing.T) {
+	tests := []struct {
+		x, y  string
+		scale int32
+		mode  RoundingMode
+		want  string
+	}{
+		{"10", "3", 4, ToNearestEven, "3.3333"},
+		{"-10", "3", 2, ToNegativeInf, "-3.34"},
+		{"2", "3", 2, ToZero, "0.66"},
+		{"1", "8", 2, ToNearestEven, "0.12"},
+		{"1", "8", 2, ToNearestAway, "0.13"},
+		{"1.25", "0.5", 0, ToNearestEven, "2"},
+		{"100", "0.03", 1, ToPositiveInf, "3333.4"},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.x).Quo(MustParse(tt.y), tt.scale, tt.mode)
+		if err != nil || got.String() != tt.want {
+			t.Fatalf("Quo(%s, %s, %d, %v) = %s, %v, want %s", tt.x, tt.y, tt.scale, tt.mode, got, err, tt.want)
+		}
+	}
+
+	if _, err := New(1, 0).Quo(Decimal{}, 2, ToNearestEven); !errors.Is(err, ErrDivisionByZero) {
+		t.Fatalf("Quo by zero: %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestScaleRange(t *testing.T) {
+	fine := New(1, MaxScale)
+	panics := map[string]func(){
+		"New":           func() { New(1, 2e9) },
+		"NewFromBigInt": func() { NewFromBigInt(fine.Coef(), -MaxScale-1) },
+		"Mul":           func() { fine.Mul(fine) },
+		"Round":         func() { New(1, 0).Round(-MaxScale-1, ToNearestEven) },
+		"Rescale":       func() { New(1, 0).Rescale(MaxScale + 1) },
+	}
+	for name, f := range panics {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s beyond MaxScale did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+
+	if _, err := New(1, 0).Quo(New(3, 0), MaxScale+1, ToNearestEven); !errors.Is(err, ErrScaleRange) {
+		t.Fatalf("Quo beyond MaxScale: %v, want ErrScaleRange", err)
+	}
+	if _, err := New(2, 0).Sqrt(MaxScale+1, ToNearestEven); !errors.Is(err, ErrScaleRange) {
+		t.Fatalf("Sqrt beyond MaxScale: %v, want ErrScaleRange", err)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int32
+		mode  RoundingMode
+		want  string
+	}{
+		{"2", 10, ToNearestEven, "1.4142135624"},
+		{"2", 10, ToZero, "1.4142135623"},
+		{"36", 0, ToNearestEven, "6"},
+		{"0.0004", 2, ToZero, "0.02"},
+		{"6.25", 0, ToNearestEven, "2"},
+		{"6.25", 0, ToNearestAway, "3"},
+		{"12345.678", 1, ToNearestEven, "111.1"},
+		{"1e-9", 6, ToNearestEven, "0.000032"},
+		{"0", 3, ToPositiveInf, "0.000"},
+	}
+
+	for _, tt := range tests {
+		got, err := MustParse(tt.in).Sqrt(tt.scale, tt.mode)
+		if err != nil || got.String() != tt.want {
+			t.Fatalf("Sqrt(%s, %d, %v) = %s, %v, want %s", tt.in, tt.scale, tt.mode, got, err, tt.want)
+		}
+	}
+
+	_, err := MustParse("-4").Sqrt(2, ToNearestEven)
+	var nerr *numeric.Error
+	if !errors.Is(err, numeric.ErrDomain) || !errors.As(err, &nerr) || nerr.Op != "decimal.Sqrt" || nerr.Input != -4 {
+		t.Fatalf("Sqrt(-4) error = %#v, want decimal.Sqrt domain error", err)
+	}
+}
+
+// TestSqrtBounds checks every mode against the definition of rounding:
+// the result r with unit u must bracket the exact root as required.
+func TestSqrtBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	modes := []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero, ToNegativeInf, ToPositiveInf}
+
+	for i := 0; i < 2000; i++ {
+		x := New(rng.Int63n(1e12), int32(rng.Intn(12)))
+		scale := int32(rng.Intn(8))
+		u := New(1, scale)
+		for _, mode := range modes {
+			r, err := x.Sqrt(scale, mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var lo, hi Decimal
+			switch mode {
+			case ToZero, ToNegativeInf:
+				lo, hi = r, r.Add(u)
+			case AwayFromZero, ToPositiveInf:
+				lo, hi = r.Sub(u), r
+			default:
+				half := New(5, scale+1)
+				lo, hi = r.Sub(half), r.Add(half)
+			}
+			if lo.Sign() < 0 {
+				lo = Decimal{}
+			}
+
+			if lo.SquareDecimal().Cmp(x) > 0 || hi.SquareDecimal().Cmp(x) < 0 {
+				t.Fatalf("Sqrt(%s, %d, %v) = %s, root outside [%s, %s]", x, scale, mode, r, lo, hi)
+			}
+		}
+	}
+}
+
+func TestAbser(t *testing.T) {
+	abs := []numeric.Abser{numeric.Float(-2.5), MustParse("-2.5")}
+	for _, a := range abs {
+		if got := a.Abs(); got != 2.5 {
+			t.Fatalf("%T.Abs() = %g, want 2.5", a, got)
+		}
+	}
+	if got := MustParse("-1.5").Square(); got != 2.25 {
+		t.Fatalf("Square(-1.5) = %g, want 2.25", got)
+	}
+}
+
+func TestFloat64AndRat(t *testing.T) {
+	d := MustParse("-0.125")
+	if d.Float64() != -0.125 {
+		t.Fatalf("Float64(%s) = %g", d, d.Float64())
+	}
+	if got := MustParse("12e3").Rat(); got.Cmp(big.NewRat(12000, 1)) != 0 {
+		t.Fatalf("Rat(12e3) = %s", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type invoice struct {
+		Total Decimal  `json:"total"`
+		Tax   Decimal  `json:"tax"`
+		Tip   *Decimal `json:"tip"`
+	}
+
+	in := `{"total":"12345678901234567890.10","tax":0.075,"tip":null}`
+	var inv invoice
+	if err := json.Unmarshal([]byte(in), &inv); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Total.String() != "12345678901234567890.10" || inv.Tax.String() != "0.075" || inv.Tip != nil {
+		t.Fatalf("decoded %+v", inv)
+	}
+
+	out, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"total":12345678901234567890.10,"tax":0.075,"tip":null}`; string(out) != want {
+		t.Fatalf("Marshal = %s, want %s", out, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"total":"abc"}`), &inv); err == nil {
+		t.Fatal("Unmarshal of a bad decimal succeeded")
+	}
+}
@@ -0,0 +1,72 @@
+package decimal
+
+import "math/big"
+
+// RoundingMode says how to round away the digits that do not fit.
+type RoundingMode int
+
+const (
+	ToNearestEven RoundingMode = iota // nearest, ties to even ("banker's rounding")
+	ToNearestAway                     // nearest, ties away from zero ("round half up")
+	ToZero                            // truncate
+	AwayFromZero                      // up in magnitude
+	ToNegativeInf                     // floor
+	ToPositiveInf                     // ceiling
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case ToNearestEven:
+		return "ToNearestEven"
+	case ToNearestAway:
+		return "ToNearestAway"
+	case ToZero:
+		return "ToZero"
+	case AwayFromZero:
+		return "AwayFromZero"
+	case ToNegativeInf:
+		return "ToNegativeInf"
+	case ToPositiveInf:
+		return "ToPositiveInf"
+	}
+	return "RoundingMode(?)"
+}
+
+// roundUp reports whether a result truncated toward zero must grow by
+// one unit in magnitude. sign is the sign of the exact result, half
+// compares the discarded fraction to one half, and odd says whether the
+// truncated result is odd. The discarded fraction must be nonzero.
+func (m RoundingMode) roundUp(sign, half int, odd bool) bool {
+	switch m {
+	case ToNearestEven:
+		return half > 0 || half == 0 && odd
+	case ToNearestAway:
+		return half >= 0
+	case ToZero:
+		return false
+	case AwayFromZero:
+		return true
+	case ToNegativeInf:
+		return sign < 0
+	case ToPositiveInf:
+		return sign > 0
+	}
+	panic("decimal: unknown rounding mode")
+}
+
+// roundQuo returns num / den rounded to an integer with mode.
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	sign := num.Sign() * den.Sign()
+	twice := new(big.Int).Abs(r)
+	half := twice.Lsh(twice, 1).Cmp(new(big.Int).Abs(den))
+
+	if mode.roundUp(sign, half, q.Bit(0) == 1) {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
@@ -0,0 +1,79 @@
+package numeric
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of numerical failure. Every *Error wraps one of them, so
+// errors.Is(err, ErrDomain) works on any error from this module.
+var (
+	// ErrDomain means the input is outside the domain of the
+	// operation, like the square root of a negative number.
+	ErrDomain = errors.New("domain error")
+
+	// ErrNoConvergence means an iteration hit Options.MaxIter before
+	// meeting its tolerance.
+	ErrNoConvergence = errors.New("no convergence")
+
+	// ErrOverflow means an iteration left the range of float64.
+	ErrOverflow = errors.New("overflow")
+)
+
+// Error records a failed numerical operation, the input that made it
+// fail and how far the iteration got.
+type Error struct {
+	Op         string  // operation, e.g. "sqrt" or "roots.Brent"
+	Input      float64 // offending input, rounded for non-float64 operations
+	Kind       error   // ErrDomain, ErrNoConvergence or ErrOverflow
+	Err        error   // more specific cause, may be nil
+	Iterations int     // iterations done before failing
+	Estimate   float64 // last estimate, if any iterations were done
+	Delta      float64 // size of the last step, if any iterations were done
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s(%v): %v", e.Op, e.Input, e.Kind)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Iterations > 0 {
+		msg += fmt.Sprintf(" after %d iterations (estimate %v, last step %v)", e.Iterations, e.Estimate, e.Delta)
+	}
+	return msg
+}
+
+// Unwrap returns the kind and the cause, so errors.Is and errors.As
+// see through to both.
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// Is reports whether target is an *Error of the same kind, and of the
+// same operation unless target.Op is empty, e.g.
+//
+//	errors.Is(err, &numeric.Error{Op: "sqrt", Kind: numeric.ErrDomain})
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return (t.Op == "" || t.Op == e.Op) && (t.Kind == nil || t.Kind == e.Kind)
+}
+
+// NewError returns an *Error of the given kind for op and input, with
+// the iteration state taken from res.
+func NewError(op string, input float64, kind, err error, res Result) *Error {
+	return &Error{
+		Op:         op,
+		Input:      input,
+		Kind:       kind,
+		Err:        err,
+		Iterations: res.Iterations,
+		Estimate:   res.Value,
+		Delta:      res.Delta,
+	}
+}
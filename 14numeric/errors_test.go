@@ -0,0 +1,49 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestErrorDomain(t *testing.T) {
+	_, err := SqrtWith(-2, DefaultOptions)
+
+	var e *Error
+	if !errors.As(err, &e) || e.Op != "sqrt" || e.Input != -2 || e.Kind != ErrDomain {
+		t.Fatalf("SqrtWith(-2) error = %#v, want *Error{Op: sqrt, Input: -2, Kind: ErrDomain}", err)
+	}
+	if !errors.Is(err, ErrDomain) || errors.Is(err, ErrNoConvergence) {
+		t.Fatalf("SqrtWith(-2) error = %v, want it to be ErrDomain only", err)
+	}
+	if !errors.Is(err, &Error{Op: "sqrt", Kind: ErrDomain}) || errors.Is(err, &Error{Op: "cbrt"}) {
+		t.Fatalf("SqrtWith(-2) error = %v, want it to match sqrt domain errors only", err)
+	}
+	if want := "sqrt(-2): domain error"; err.Error() != want {
+		t.Fatalf("SqrtWith(-2) error = %q, want %q", err, want)
+	}
+}
+
+func TestErrorNoConvergence(t *testing.T) {
+	res, err := SqrtWith(1e300, Options{MaxIter: 3})
+
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrNoConvergence) {
+		t.Fatalf("SqrtWith(1e300, MaxIter 3) error = %v, want ErrNoConvergence *Error", err)
+	}
+	if e.Iterations != 3 || e.Estimate != res.Value || e.Delta != res.Delta || math.IsNaN(e.Estimate) {
+		t.Fatalf("error state = %+v, want the state of result %+v", e, res)
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	cause := errors.New("root is not bracketed")
+	err := error(NewError("roots.Brent", 0, ErrDomain, cause, Result{}))
+
+	if !errors.Is(err, cause) || !errors.Is(err, ErrDomain) {
+		t.Fatalf("error %v does not match both its kind and cause", err)
+	}
+	if want := "roots.Brent(0): domain error: root is not bracketed"; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,22 @@
+package numeric
+
+// Abser is implemented by values that have an absolute value, like
+// MyFloat and *MyVertex in the methods lesson (10meth).
+type Abser interface {
+	Abs() float64
+}
+
+// Float is MyFloat from the methods lesson, in a package other
+// modules can import.
+type Float float64
+
+func (f Float) Abs() float64 {
+	if f < 0 {
+		return float64(-f)
+	}
+	return float64(f)
+}
+
+func (f Float) Square() float64 {
+	return float64(f * f)
+}
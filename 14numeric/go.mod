@@ -0,0 +1,3 @@
+module example.com/numeric
+
+go 1.21.3
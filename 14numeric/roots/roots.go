@@ -0,0 +1,332 @@
+// Package roots finds roots of arbitrary functions f(x) = 0.
+//
+// Every method reports its outcome as a numeric.Result and stops
+// according to numeric.Options, like numeric.SqrtWith. Failures are
+// *numeric.Error values whose Input is the starting point, or the lower
+// end of the bracket.
+package roots
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"example.com/numeric"
+)
+
+// Causes of the numeric.ErrDomain errors returned by the methods.
+var (
+	// ErrNoBracket is returned by the bracketing methods when f(a)
+	// and f(b) do not have opposite signs.
+	ErrNoBracket = errors.New("root is not bracketed")
+
+	// ErrZeroDerivative is returned when a Newton or secant step
+	// would divide by a zero slope.
+	ErrZeroDerivative = errors.New("zero derivative")
+)
+
+// Func is a function of one variable.
+type Func func(x float64) float64
+
+// Derivative returns a central difference estimate of the derivative of f.
+func Derivative(f Func) Func {
+	// cbrt(eps) balances truncation and rounding error for central differences
+	const step = 6.0554544523933395e-06
+
+	return func(x float64) float64 {
+		h := step * math.Max(math.Abs(x), 1)
+		return (f(x+h) - f(x-h)) / (2 * h)
+	}
+}
+
+// Newton finds a root of f starting from x0 with Newton-Raphson steps
+// x -= f(x) / df(x). If df is nil it is estimated with Derivative.
+func Newton(f, df Func, x0 float64, opts numeric.Options) (numeric.Result, error) {
+	if df == nil {
+		df = Derivative(f)
+	}
+
+	res := numeric.Result{Value: x0}
+	x := x0
+	for res.Iterations < opts.MaxIter {
+		fx := f(x)
+		if fx == 0 {
+			res.Converged = true
+			return res, nil
+		}
+
+		d := df(x)
+		if d == 0 || math.IsNaN(d) {
+			return res, numeric.NewError("roots.Newton", x0, numeric.ErrDomain, ErrZeroDerivative, res)
+		}
+
+		delta := fx / d
+		x -= delta
+
+		res.Iterations++
+		res.Value = x
+		res.Delta = math.Abs(delta)
+		trace(opts, "newton", res)
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return res, numeric.NewError("roots.Newton", x0, numeric.ErrOverflow, nil, res)
+		}
+		if done(res, opts) {
+			res.Converged = true
+			return res, nil
+		}
+	}
+
+	return res, numeric.NewError("roots.Newton", x0, numeric.ErrNoConvergence, nil, res)
+}
+
+// Secant finds a root of f from the two starting points x0 and x1,
+// replacing the derivative in Newton's method with the slope of the
+// line through the last two points.
+func Secant(f Func, x0, x1 float64, opts numeric.Options) (numeric.Result, error) {
+	start := x1
+	f0, f1 := f(x0), f(x1)
+
+	res := numeric.Result{Value: x1}
+	for res.Iterations < opts.MaxIter {
+		if f1 == 0 {
+			res.Converged = true
+			return res, nil
+		}
+		if f1 == f0 {
+			return res, numeric.NewError("roots.Secant", start, numeric.ErrDomain, ErrZeroDerivative, res)
+		}
+
+		delta := f1 * (x1 - x0) / (f1 - f0)
+		x0, f0 = x1, f1
+		x1 -= delta
+		f1 = f(x1)
+
+		res.Iterations++
+		res.Value = x1
+		res.Delta = math.Abs(delta)
+		trace(opts, "secant", res)
+		if math.IsInf(x1, 0) || math.IsNaN(x1) {
+			return res, numeric.NewError("roots.Secant", start, numeric.ErrOverflow, nil, res)
+		}
+		if done(res, opts) {
+			res.Converged = true
+			return res, nil
+		}
+	}
+
+	return res, numeric.NewError("roots.Secant", start, numeric.ErrNoConvergence, nil, res)
+}
+
+// Bisect finds a root of f in [a, b] by repeatedly halving the interval.
+// f(a) and f(b) must have opposite signs. Delta is half the width of
+// the final interval.
+func Bisect(f Func, a, b float64, opts numeric.Options) (numeric.Result, error) {
+	fa, fb := f(a), f(b)
+	if res, ok := atEndpoint(a, fa, b, fb); ok {
+		return res, nil
+	}
+	if math.Signbit(fa) == math.Signbit(fb) {
+		return numeric.Result{}, numeric.NewError("roots.Bisect", a, numeric.ErrDomain, ErrNoBracket, numeric.Result{})
+	}
+
+	start := a
+
+	var res numeric.Result
+	for res.Iterations < opts.MaxIter {
+		m := a + (b-a)/2
+		if math.Signbit(a) != math.Signbit(b) && a != 0 && b != 0 {
+			// Split at 0 first: halving alone would need over a
+			// thousand steps to pin down a root at 0, where the
+			// relative tolerance never applies.
+			m = 0
+		}
+		fm := f(m)
+
+		res.Iterations++
+		res.Value = m
+		res.Delta = math.Abs(b-a) / 2
+		trace(opts, "bisect", res)
+		// Once m equals an end, a and b are adjacent floats and the
+		// interval cannot shrink further, whatever the tolerance.
+		if fm == 0 || m == a || m == b || done(res, opts) {
+			res.Converged = true
+			return res, nil
+		}
+
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+
+	return res, numeric.NewError("roots.Bisect", start, numeric.ErrNoConvergence, nil, res)
+}
+
+// Brent finds a root of f in [a, b] with Brent's method, which combines
+// the guaranteed progress of bisection with inverse quadratic and secant
+// interpolation. f(a) and f(b) must have opposite signs.
+func Brent(f Func, a, b float64, opts numeric.Options) (numeric.Result, error) {
+	fa, fb := f(a), f(b)
+	if res, ok := atEndpoint(a, fa, b, fb); ok {
+		return res, nil
+	}
+	if math.Signbit(fa) == math.Signbit(fb) {
+		return numeric.Result{}, numeric.NewError("roots.Brent", a, numeric.ErrDomain, ErrNoBracket, numeric.Result{})
+	}
+
+	// b is the best estimate so far, a the previous one and c the
+	// point that keeps the root bracketed between b and c
+	start := a
+	c, fc := a, fa
+	d := b - a
+	e := d
+
+	var res numeric.Result
+	for res.Iterations < opts.MaxIter {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := max(opts.AbsTol, opts.RelTol*math.Abs(b)) / 2
+		m := (c - b) / 2
+
+		res.Iterations++
+		res.Value = b
+		res.Delta = math.Abs(m)
+		trace(opts, "brent", res)
+		if fb == 0 || math.Abs(m) <= tol {
+			res.Converged = true
+			return res, nil
+		}
+
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// try interpolation
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// secant
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				// inverse quadratic
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+
+			if 2*p < min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
+		} else {
+			d = m
+			e = d
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, m)
+		}
+		fb = f(b)
+	}
+
+	return res, numeric.NewError("roots.Brent", start, numeric.ErrNoConvergence, nil, res)
+}
+
+// NthRoot returns the real nth root of x using Newton's method.
+// For even n and negative x, or n < 1, the result is NaN with an
+// numeric.ErrDomain error.
+func NthRoot(x float64, n int, opts numeric.Options) (numeric.Result, error) {
+	switch {
+	case n <= 0:
+		return numeric.Result{Value: math.NaN()},
+			numeric.NewError("roots.NthRoot", x, numeric.ErrDomain, fmt.Errorf("root index %d < 1", n), numeric.Result{})
+	case math.IsNaN(x):
+		return numeric.Result{Value: x, Converged: true}, nil
+	case n == 1 || x == 0 || math.IsInf(x, 0) && (x > 0 || n%2 == 1):
+		return numeric.Result{Value: x, Converged: true}, nil
+	case x < 0 && n%2 == 0:
+		return numeric.Result{Value: math.NaN()},
+			numeric.NewError("roots.NthRoot", x, numeric.ErrDomain, errors.New("even root of negative number"), numeric.Result{})
+	case x < 0:
+		// odd roots of negative numbers are negated roots of |x|
+		res, err := NthRoot(-x, n, opts)
+		res.Value = -res.Value
+		var nerr *numeric.Error
+		if errors.As(err, &nerr) {
+			err = numeric.NewError("roots.NthRoot", x, nerr.Kind, nerr.Err, res)
+		}
+		return res, err
+	}
+
+	// Split x into frac·2^exp and take the root of y = frac·2^r with
+	// exp = n·e + r and -n < r <= 0, so y < 1 and z^n cannot overflow
+	// however large x is. The root of x is then root(y)·2^e.
+	frac, exp := math.Frexp(x)
+	e := exp / n
+	if exp > 0 && exp%n != 0 {
+		e++
+	}
+	y := math.Ldexp(frac, exp-n*e)
+
+	fn := float64(n)
+
+	// Start just above the root so Newton descends monotonically. From
+	// further away, even a factor of two, z^n overflows for large n.
+	y0 := math.Nextafter(math.Pow(y, 1/fn), math.Inf(1))
+	f := func(z float64) float64 { return math.Pow(z, fn) - y }
+	df := func(z float64) float64 { return fn * math.Pow(z, fn-1) }
+
+	res, err := Newton(f, df, y0, opts)
+	res.Value = math.Ldexp(res.Value, e)
+	res.Delta = math.Ldexp(res.Delta, e)
+	var nerr *numeric.Error
+	if errors.As(err, &nerr) {
+		err = numeric.NewError("roots.NthRoot", x, nerr.Kind, nerr.Err, res)
+	}
+	return res, err
+}
+
+// Cbrt returns the cube root of x.
+func Cbrt(x float64, opts numeric.Options) (numeric.Result, error) {
+	return NthRoot(x, 3, opts)
+}
+
+// trace reports the latest step of res to the tracer of opts.
+func trace(opts numeric.Options, op string, res numeric.Result) {
+	opts.Trace(op, numeric.Step{Iteration: res.Iterations, Estimate: res.Value, Delta: res.Delta})
+}
+
+// done reports whether the last step of res is within the tolerances.
+func done(res numeric.Result, opts numeric.Options) bool {
+	return res.Delta <= opts.AbsTol || res.Delta <= opts.RelTol*math.Abs(res.Value)
+}
+
+// atEndpoint handles brackets where a or b is already a root.
+func atEndpoint(a, fa, b, fb float64) (numeric.Result, bool) {
+	switch {
+	case fa == 0:
+		return numeric.Result{Value: a, Converged: true}, true
+	case fb == 0:
+		return numeric.Result{Value: b, Converged: true}, true
+	}
+	return numeric.Result{}, false
+}
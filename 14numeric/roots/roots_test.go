@@ -0,0 +1,191 @@
+package roots
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"example.com/numeric"
+)
+
+var opts = numeric.Options{RelTol: 1e-14, AbsTol: 1e-300, MaxIter: 200}
+
+func TestMethods(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Func
+		a, b float64
+		want float64
+	}{
+		{"sqrt2", func(x float64) float64 { return x*x - 2 }, 0, 2, math.Sqrt2},
+		{"dottie", func(x float64) float64 { return math.Cos(x) - x }, 0, 1, 0.7390851332151607},
+		{"cubic", func(x float64) float64 { return x*x*x - 2*x - 5 }, 2, 3, 2.0945514815423265},
+		{"exp", func(x float64) float64 { return math.Exp(x) - 10 }, 0, 5, math.Log(10)},
+	}
+
+	methods := []struct {
+		name  string
+		solve func(f Func, a, b float64) (numeric.Result, error)
+	}{
+		{"Newton", func(f Func, a, b float64) (numeric.Result, error) { return Newton(f, nil, b, opts) }},
+		{"Secant", func(f Func, a, b float64) (numeric.Result, error) { return Secant(f, a, b, opts) }},
+		{"Bisect", func(f Func, a, b float64) (numeric.Result, error) { return Bisect(f, a, b, opts) }},
+		{"Brent", func(f Func, a, b float64) (numeric.Result, error) { return Brent(f, a, b, opts) }},
+	}
+
+	for _, m := range methods {
+		for _, tt := range tests {
+			res, err := m.solve(tt.f, tt.a, tt.b)
+			if err != nil || !res.Converged || math.Abs(res.Value-tt.want) > 1e-12 {
+				t.Fatalf("%s(%s) = %+v, %v, want %.17g", m.name, tt.name, res, err, tt.want)
+			}
+		}
+	}
+}
+
+func TestBrentFasterThanBisect(t *testing.T) {
+	f := func(x float64) float64 { return math.Cos(x) - x }
+
+	brent, _ := Brent(f, 0, 1, opts)
+	bisect, _ := Bisect(f, 0, 1, opts)
+	if brent.Iterations >= bisect.Iterations {
+		t.Fatalf("Brent took %d iterations, Bisect %d; want Brent to be faster", brent.Iterations, bisect.Iterations)
+	}
+}
+
+func TestBisectExhaustsFloats(t *testing.T) {
+	// Neither tolerance can stop these: a root at 0 has no relative
+	// tolerance, and exact tolerances are never met. Bisect must stop
+	// once a and b are adjacent instead of running to MaxIter.
+	exact := numeric.Options{MaxIter: 100}
+	tests := []struct {
+		name string
+		f    Func
+		opts numeric.Options
+	}{
+		{"x", func(x float64) float64 { return x }, numeric.DefaultOptions},
+		{"x²-2", func(x float64) float64 { return x*x - 2 }, exact},
+	}
+
+	for _, tt := range tests {
+		res, err := Bisect(tt.f, -1, 2, tt.opts)
+		lo, hi := math.Nextafter(res.Value, -1), math.Nextafter(res.Value, 2)
+		if err != nil || !res.Converged || math.Signbit(tt.f(lo)) == math.Signbit(tt.f(hi)) {
+			t.Fatalf("Bisect(%s, -1, 2) = %+v, %v, want the root within an ulp", tt.name, res, err)
+		}
+	}
+}
+
+func TestNoBracket(t *testing.T) {
+	f := func(x float64) float64 { return x*x + 1 }
+
+	if _, err := Bisect(f, -1, 1, opts); !errors.Is(err, ErrNoBracket) || !errors.Is(err, numeric.ErrDomain) {
+		t.Fatalf("Bisect(x²+1, -1, 1) error = %v, want ErrNoBracket", err)
+	}
+	if _, err := Brent(f, -1, 1, opts); !errors.Is(err, ErrNoBracket) {
+		t.Fatalf("Brent(x²+1, -1, 1) error = %v, want ErrNoBracket", err)
+	}
+}
+
+func TestNewtonZeroDerivative(t *testing.T) {
+	f := func(x float64) float64 { return x*x + 1 }
+	if _, err := Newton(f, nil, 0, opts); !errors.Is(err, ErrZeroDerivative) {
+		t.Fatalf("Newton(x²+1, 0) error = %v, want ErrZeroDerivative", err)
+	}
+}
+
+func TestNewtonOverflow(t *testing.T) {
+	// Newton's method on ∛x steps from x to -2x, so it runs off to ±Inf
+	dcbrt := func(x float64) float64 { c := math.Cbrt(x); return 1 / (3 * c * c) }
+	o := opts
+	o.MaxIter = 2000
+	_, err := Newton(math.Cbrt, dcbrt, 2, o)
+
+	var e *numeric.Error
+	if !errors.As(err, &e) || e.Kind != numeric.ErrOverflow || e.Op != "roots.Newton" || e.Input != 2 {
+		t.Fatalf("Newton(cbrt, 2) error = %v, want roots.Newton overflow from input 2", err)
+	}
+}
+
+func TestNthRootDomain(t *testing.T) {
+	for _, n := range []int{2, 0} {
+		res, err := NthRoot(-4, n, opts)
+		if !math.IsNaN(res.Value) || res.Converged || !errors.Is(err, numeric.ErrDomain) {
+			t.Fatalf("NthRoot(-4, %d) = %+v, %v, want NaN and a domain error", n, res, err)
+		}
+	}
+}
+
+func TestNthRoot(t *testing.T) {
+	tests := []struct {
+		x    float64
+		n    int
+		want float64
+	}{
+		{27, 3, 3},
+		{-27, 3, -3},
+		{2, 2, math.Sqrt2},
+		{1e300, 5, 1e60},
+		{1e300, 20, 1e15},
+		{1e300, 50, 1e6},
+		{1e300, 100, 1e3},
+		{1e-300, 100, 1e-3},
+		{1e300, 70, math.Pow(1e300, 1.0/70)},
+		{1e308, 1000, math.Pow(1e308, 1e-3)},
+		{1e-300, 3, 1e-100},
+		{math.MaxFloat64, 2, math.Sqrt(math.MaxFloat64)},
+		{math.MaxFloat64, 3, math.Cbrt(math.MaxFloat64)},
+		{-math.MaxFloat64, 3, -math.Cbrt(math.MaxFloat64)},
+		{math.SmallestNonzeroFloat64, 3, math.Cbrt(math.SmallestNonzeroFloat64)},
+		{0.5, 7, math.Pow(0.5, 1.0/7)},
+		{math.Inf(-1), 3, math.Inf(-1)},
+		{0, 4, 0},
+	}
+
+	for _, tt := range tests {
+		res, err := NthRoot(tt.x, tt.n, numeric.DefaultOptions)
+		if math.IsNaN(tt.want) {
+			if !math.IsNaN(res.Value) {
+				t.Fatalf("NthRoot(%g, %d) = %g, want NaN", tt.x, tt.n, res.Value)
+			}
+			continue
+		}
+		if err != nil || math.Abs(res.Value-tt.want) > 1e-14*math.Abs(tt.want) {
+			t.Fatalf("NthRoot(%g, %d) = %+v, %v, want %g", tt.x, tt.n, res, err, tt.want)
+		}
+	}
+}
+
+func TestNthRootError(t *testing.T) {
+	o := numeric.DefaultOptions
+	o.MaxIter = 0
+	for _, x := range []float64{math.MaxFloat64, -1e300} {
+		_, err := NthRoot(x, 7, o)
+		var e *numeric.Error
+		if !errors.As(err, &e) || e.Op != "roots.NthRoot" || e.Input != x || !errors.Is(err, numeric.ErrNoConvergence) {
+			t.Fatalf("NthRoot(%g, 7) with MaxIter 0: err = %v, want roots.NthRoot(%g) no convergence", x, err, x)
+		}
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	df := Derivative(math.Sin)
+	for _, x := range []float64{-3, 0, 1, 10} {
+		if got, want := df(x), math.Cos(x); math.Abs(got-want) > 1e-8 {
+			t.Fatalf("Derivative(sin)(%g) = %g, want %g", x, got, want)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var ops []string
+	o := opts
+	o.Tracer = numeric.TracerFunc(func(op string, s numeric.Step) {
+		ops = append(ops, op)
+	})
+
+	res, err := Brent(func(x float64) float64 { return x*x - 2 }, 0, 2, o)
+	if err != nil || len(ops) != res.Iterations || ops[0] != "brent" {
+		t.Fatalf("Brent traced %q in %d iterations, %v; want one brent step per iteration", ops, res.Iterations, err)
+	}
+}
@@ -0,0 +1,82 @@
+package numeric
+
+import "math"
+
+// Options controls when an iterative routine stops. It stops as soon as
+// the size of a step is within AbsTol or within RelTol of the current
+// estimate, or after MaxIter steps. Every step is reported to Tracer.
+type Options struct {
+	RelTol  float64
+	AbsTol  float64
+	MaxIter int
+	Tracer  Tracer // nil for no tracing
+}
+
+// DefaultOptions stops within a couple of ulps of the answer.
+var DefaultOptions = Options{
+	RelTol:  4 * 0x1p-52,
+	AbsTol:  0,
+	MaxIter: 100,
+}
+
+// Result is the outcome of an iterative routine.
+type Result struct {
+	Value      float64
+	Iterations int
+	Delta      float64 // size of the last step, an estimate of the remaining error
+	Converged  bool    // false whenever an error is returned with the Result
+}
+
+// Sqrt returns the square root of x using Newton's method with
+// DefaultOptions. Special cases are the same as for math.Sqrt.
+func Sqrt(x float64) float64 {
+	res, _ := SqrtWith(x, DefaultOptions)
+	return res.Value
+}
+
+// SqrtWith returns the square root of x using Newton's method,
+// z -= (z - x/z) / 2, stopping according to opts.
+//
+// Special cases follow IEEE-754 and need no iterations:
+//
+//	SqrtWith(±0) = ±0
+//	SqrtWith(+Inf) = +Inf
+//	SqrtWith(x < 0) = NaN, with an ErrDomain *Error
+//	SqrtWith(NaN) = NaN
+//
+// If opts.MaxIter is reached first, the last estimate is returned
+// together with an ErrNoConvergence *Error.
+func SqrtWith(x float64, opts Options) (Result, error) {
+	switch {
+	case x == 0 || math.IsInf(x, 1):
+		return Result{Value: x, Converged: true}, nil
+	case math.IsNaN(x):
+		return Result{Value: x, Converged: true}, nil
+	case x < 0:
+		// the invalid operation exception of IEEE-754
+		return Result{Value: math.NaN()}, NewError("sqrt", x, ErrDomain, nil, Result{})
+	}
+
+	// start from 2^(e/2) where x = frac * 2^e, which is within a
+	// factor of two of the answer whatever the magnitude of x
+	_, exp := math.Frexp(x)
+	z := math.Ldexp(1, exp/2)
+
+	res := Result{Value: z}
+	for res.Iterations < opts.MaxIter {
+		delta := (z - x/z) / 2
+		z -= delta
+
+		res.Iterations++
+		res.Value = z
+		res.Delta = math.Abs(delta)
+		opts.Trace("sqrt", Step{Iteration: res.Iterations, Estimate: z, Delta: res.Delta})
+
+		if res.Delta <= opts.AbsTol || res.Delta <= opts.RelTol*z {
+			res.Converged = true
+			return res, nil
+		}
+	}
+
+	return res, NewError("sqrt", x, ErrNoConvergence, nil, res)
+}
@@ -0,0 +1,77 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSqrtMagnitudes(t *testing.T) {
+	tests := []float64{
+		math.SmallestNonzeroFloat64, 5e-324 * 3, 1e-310, 1e-300, 1e-100, 1e-10,
+		0.25, 0.5, 1, 2, 3, 36, 1e10, 12345.6789, 1e100, 1e300, math.MaxFloat64,
+	}
+
+	for _, x := range tests {
+		res, err := SqrtWith(x, DefaultOptions)
+		want := math.Sqrt(x)
+		if err != nil || !res.Converged {
+			t.Fatalf("SqrtWith(%g) = %+v, %v, want convergence", x, res, err)
+		}
+		if diff := math.Abs(res.Value - want); diff > 2*ulp(want) {
+			t.Fatalf("SqrtWith(%g) = %g, want %g (off by %g)", x, res.Value, want, diff)
+		}
+		if res.Iterations == 0 || res.Iterations > 10 {
+			t.Fatalf("SqrtWith(%g) took %d iterations, want 1..10", x, res.Iterations)
+		}
+	}
+}
+
+func TestSqrtSpecialCases(t *testing.T) {
+	tests := []struct {
+		x, want float64
+		err     error
+	}{
+		{0, 0, nil},
+		{math.Copysign(0, -1), math.Copysign(0, -1), nil},
+		{math.Inf(1), math.Inf(1), nil},
+		{-1, math.NaN(), ErrDomain},
+		{math.Inf(-1), math.NaN(), ErrDomain},
+		{math.NaN(), math.NaN(), nil},
+	}
+
+	for _, tt := range tests {
+		res, err := SqrtWith(tt.x, DefaultOptions)
+		if !errors.Is(err, tt.err) || res.Iterations != 0 || !same(res.Value, tt.want) || res.Converged != (err == nil) {
+			t.Fatalf("SqrtWith(%g) = %+v, %v, want %g, %v in 0 iterations", tt.x, res, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestSqrtMaxIter(t *testing.T) {
+	res, err := SqrtWith(1e300, Options{MaxIter: 2})
+	if !errors.Is(err, ErrNoConvergence) || res.Converged || res.Iterations != 2 {
+		t.Fatalf("SqrtWith(1e300, MaxIter 2) = %+v, %v, want ErrNoConvergence after 2 iterations", res, err)
+	}
+}
+
+func TestSqrtAbsTol(t *testing.T) {
+	res, err := SqrtWith(2, Options{AbsTol: 1e-3, MaxIter: 100})
+	if err != nil || math.Abs(res.Value-math.Sqrt2) > 1e-3 || res.Delta > 1e-3 {
+		t.Fatalf("SqrtWith(2, AbsTol 1e-3) = %+v, %v, want within 1e-3 of √2", res, err)
+	}
+}
+
+// ulp returns the distance from x to the next float64 away from zero.
+func ulp(x float64) float64 {
+	return math.Nextafter(x, math.Inf(1)) - x
+}
+
+// same reports whether x and y are the same value, treating NaNs as
+// equal and distinguishing +0 from -0.
+func same(x, y float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) && math.IsNaN(y)
+	}
+	return x == y && math.Signbit(x) == math.Signbit(y)
+}
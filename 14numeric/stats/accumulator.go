@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"math"
+
+	"example.com/numeric"
+)
+
+// Accumulator computes the count, mean, variance, minimum and maximum
+// of a stream of values in one pass and constant space, using Welford's
+// algorithm. The zero value is an empty accumulator.
+//
+// An Accumulator is not safe for concurrent use. To spread the work over
+// goroutines, give each its own Accumulator and Merge them at the end.
+type Accumulator struct {
+	n        int64
+	mean, m2 float64 // m2 is the sum of squared deviations from mean
+	min, max float64
+}
+
+// Accumulate returns an Accumulator holding all of xs.
+func Accumulate[T Number](xs []T) Accumulator {
+	var a Accumulator
+	for _, x := range xs {
+		a.Add(float64(x))
+	}
+	return a
+}
+
+// Add adds x to the accumulator.
+func (a *Accumulator) Add(x float64) {
+	a.n++
+	if a.n == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min, a.max = min(a.min, x), max(a.max, x)
+	}
+
+	delta := x - a.mean
+	a.mean += delta / float64(a.n)
+	a.m2 += delta * (x - a.mean)
+}
+
+// Merge adds every value added to b to a, as if they had been added to
+// a directly (Chan et al.'s parallel update).
+func (a *Accumulator) Merge(b Accumulator) {
+	switch {
+	case b.n == 0:
+		return
+	case a.n == 0:
+		*a = b
+		return
+	}
+
+	n := a.n + b.n
+	delta := b.mean - a.mean
+	a.mean += delta * float64(b.n) / float64(n)
+	a.m2 += b.m2 + delta*delta*float64(a.n)*float64(b.n)/float64(n)
+	a.min, a.max = min(a.min, b.min), max(a.max, b.max)
+	a.n = n
+}
+
+// Count returns the number of values added.
+func (a *Accumulator) Count() int64 {
+	return a.n
+}
+
+// Mean returns the mean of the values added.
+func (a *Accumulator) Mean() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmpty
+	}
+	return a.mean, nil
+}
+
+// Variance returns the sample variance of the values added, which
+// needs at least two of them.
+func (a *Accumulator) Variance() (float64, error) {
+	if a.n < 2 {
+		return math.NaN(), ErrEmpty
+	}
+	return a.m2 / float64(a.n-1), nil
+}
+
+// PopVariance returns the population variance of the values added.
+func (a *Accumulator) PopVariance() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmpty
+	}
+	return a.m2 / float64(a.n), nil
+}
+
+// StdDev returns the sample standard deviation of the values added,
+// the square root of Variance taken with numeric.Sqrt.
+func (a *Accumulator) StdDev() (float64, error) {
+	v, err := a.Variance()
+	if err != nil {
+		return v, err
+	}
+	return numeric.Sqrt(v), nil
+}
+
+// Min returns the smallest value added.
+func (a *Accumulator) Min() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmpty
+	}
+	return a.min, nil
+}
+
+// Max returns the largest value added.
+func (a *Accumulator) Max() (float64, error) {
+	if a.n == 0 {
+		return math.NaN(), ErrEmpty
+	}
+	return a.max, nil
+}
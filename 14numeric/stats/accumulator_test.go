@@ -0,0 +1,125 @@
+package stats
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestAccumulatorStreaming(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	xs := make([]float64, 10000)
+	for i := range xs {
+		xs[i] = 1e9 + rng.NormFloat64() // a large offset defeats the naive sum-of-squares formula
+	}
+
+	var a Accumulator
+	for _, x := range xs {
+		a.Add(x)
+	}
+
+	v, _ := a.Variance()
+	mean, _ := a.Mean()
+	want := twoPassVariance(xs)
+	if !closeTo(v, want, 1e-6) {
+		t.Fatalf("Variance = %.15g, want %.15g", v, want)
+	}
+	if m, _ := Mean(xs); !closeTo(mean, m, 1e-15) {
+		t.Fatalf("Mean = %.17g, want %.17g", mean, m)
+	}
+	if a.Count() != int64(len(xs)) {
+		t.Fatalf("Count = %d", a.Count())
+	}
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	xs := make([]int, 1001)
+	for i := range xs {
+		xs[i] = (i * 7919) % 1000
+	}
+	whole := Accumulate(xs)
+
+	// one accumulator per goroutine, merged as the results come in
+	const workers = 4
+	parts := make(chan Accumulator)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := w*len(xs)/workers, (w+1)*len(xs)/workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts <- Accumulate(xs[lo:hi])
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(parts)
+	}()
+
+	var merged Accumulator
+	merged.Merge(Accumulator{})
+	for p := range parts {
+		merged.Merge(p)
+	}
+	merged.Merge(Accumulator{})
+
+	if merged.Count() != whole.Count() {
+		t.Fatalf("Count = %d, want %d", merged.Count(), whole.Count())
+	}
+	for name, f := range map[string]func(*Accumulator) (float64, error){
+		"Mean": (*Accumulator).Mean, "Variance": (*Accumulator).Variance,
+		"StdDev": (*Accumulator).StdDev, "Min": (*Accumulator).Min, "Max": (*Accumulator).Max,
+	} {
+		got, _ := f(&merged)
+		want, _ := f(&whole)
+		if !closeTo(got, want, 1e-12) {
+			t.Fatalf("merged %s = %.17g, want %.17g", name, got, want)
+		}
+	}
+}
+
+func TestAccumulatorMinMax(t *testing.T) {
+	a := Accumulate([]int{3, -2, 8})
+	lo, _ := a.Min()
+	hi, _ := a.Max()
+	if lo != -2 || hi != 8 {
+		t.Fatalf("Min, Max = %g, %g, want -2, 8", lo, hi)
+	}
+
+	var empty Accumulator
+	if _, err := empty.Min(); err != ErrEmpty {
+		t.Fatalf("Min of empty: %v", err)
+	}
+}
+
+func twoPassVariance(xs []float64) float64 {
+	var mean float64
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return ss / float64(len(xs)-1)
+}
+
+func closeTo(a, b, rel float64) bool {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	if b < 0 {
+		b = -b
+	}
+	return d <= rel*max(b, 1)
+}
+
+func BenchmarkAccumulatorAdd(b *testing.B) {
+	var a Accumulator
+	for i := 0; i < b.N; i++ {
+		a.Add(float64(i))
+	}
+}
@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Histogram counts values into bins. Bin i holds the values in
+// [Edges[i], Edges[i+1]); the last bin also holds its upper edge.
+type Histogram struct {
+	Edges  []float64 // len(Counts)+1 ascending bin edges
+	Counts []int
+}
+
+// NewHistogram returns a histogram of xs with bins equal-width bins
+// spanning the smallest to the largest value.
+func NewHistogram[T Number](xs []T, bins int) (*Histogram, error) {
+	if len(xs) == 0 {
+		return nil, ErrEmpty
+	}
+	lo, hi := float64(slices.Min(xs)), float64(slices.Max(xs))
+	if lo == hi {
+		// A single distinct value still needs a bin of some width.
+		// Beyond 2^53, ±0.5 would round back to the value itself.
+		d := max(0.5, math.Abs(lo)*0x1p-20)
+		lo, hi = max(lo-d, -math.MaxFloat64), min(hi+d, math.MaxFloat64)
+	}
+
+	h, err := NewHistogramRange(lo, hi, bins)
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range xs {
+		h.Add(float64(x))
+	}
+	return h, nil
+}
+
+// NewHistogramRange returns an empty histogram with bins equal-width
+// bins spanning [lo, hi], to be filled with Add.
+func NewHistogramRange(lo, hi float64, bins int) (*Histogram, error) {
+	if bins < 1 {
+		return nil, fmt.Errorf("stats: histogram needs at least one bin, got %d", bins)
+	}
+	if !(lo < hi) {
+		return nil, fmt.Errorf("stats: bad histogram range [%g, %g]", lo, hi)
+	}
+
+	// hi - lo can overflow even when lo and hi are finite, so work in
+	// halves, which are exact and cannot
+	edges := make([]float64, bins+1)
+	half := (hi/float64(bins) - lo/float64(bins)) / 2
+	for i := range edges {
+		edges[i] = 2 * (lo/2 + float64(i)*half)
+	}
+	edges[bins] = hi
+
+	return &Histogram{Edges: edges, Counts: make([]int, bins)}, nil
+}
+
+// Add counts x into its bin and reports whether x was in range.
+func (h *Histogram) Add(x float64) bool {
+	n := len(h.Counts)
+	if !(h.Edges[0] <= x && x <= h.Edges[n]) {
+		return false
+	}
+
+	// the bin whose lower edge is the last one <= x
+	i, found := slices.BinarySearch(h.Edges, x)
+	if !found {
+		i--
+	}
+	h.Counts[min(i, n-1)]++
+	return true
+}
+
+// Total returns the number of values counted.
+func (h *Histogram) Total() int {
+	total := 0
+	for _, c := range h.Counts {
+		total += c
+	}
+	return total
+}
+
+// String draws the histogram as text bars, one line per bin, with the
+// fullest bin drawn barWidth characters wide.
+func (h *Histogram) String() string {
+	const barWidth = 40
+	most := max(slices.Max(h.Counts), 1)
+
+	var b strings.Builder
+	for i, c := range h.Counts {
+		bar := strings.Repeat("*", (c*barWidth+most-1)/most)
+		fmt.Fprintf(&b, "[%8.3g, %8.3g) %6d %s\n", h.Edges[i], h.Edges[i+1], c, bar)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	h, err := NewHistogram([]int{0, 1, 2, 2, 3, 5, 9, 10}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []float64{0, 2, 4, 6, 8, 10}; !slices.Equal(h.Edges, want) {
+		t.Fatalf("Edges = %v, want %v", h.Edges, want)
+	}
+	// 10 is the upper edge and lands in the last bin
+	if want := []int{2, 3, 1, 0, 2}; !slices.Equal(h.Counts, want) {
+		t.Fatalf("Counts = %v, want %v", h.Counts, want)
+	}
+	if h.Total() != 8 {
+		t.Fatalf("Total = %d", h.Total())
+	}
+
+	if h.Add(-0.1) || h.Add(10.1) {
+		t.Fatal("Add accepted an out-of-range value")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(h.String(), "\n"), "\n")
+	if len(lines) != 5 || !strings.HasSuffix(lines[1], " 3 "+strings.Repeat("*", 40)) || strings.Contains(lines[3], "*") {
+		t.Fatalf("String =\n%s", h)
+	}
+}
+
+func TestHistogramSingleValue(t *testing.T) {
+	h, err := NewHistogram([]float32{4, 4, 4}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Total() != 3 || h.Edges[0] != 3.5 || h.Edges[3] != 4.5 {
+		t.Fatalf("histogram = %+v", h)
+	}
+}
+
+func TestHistogramExtremes(t *testing.T) {
+	for _, x := range []float64{1e20, -1e300, math.MaxFloat64} {
+		h, err := NewHistogram([]float64{x, x}, 3)
+		if err != nil || h.Total() != 2 {
+			t.Fatalf("NewHistogram(%g, %g) = %+v, %v, want both counted", x, x, h, err)
+		}
+	}
+
+	h, err := NewHistogram([]float64{-math.MaxFloat64, 0, math.MaxFloat64}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{-math.MaxFloat64, 0, math.MaxFloat64}; !slices.Equal(h.Edges, want) {
+		t.Fatalf("Edges = %v, want %v", h.Edges, want)
+	}
+	if want := []int{1, 2}; !slices.Equal(h.Counts, want) {
+		t.Fatalf("Counts = %v, want %v", h.Counts, want)
+	}
+}
+
+func TestHistogramErrors(t *testing.T) {
+	if _, err := NewHistogram([]int{}, 3); err != ErrEmpty {
+		t.Fatalf("empty: %v", err)
+	}
+	if _, err := NewHistogram([]int{1, 2}, 0); err == nil {
+		t.Fatal("zero bins accepted")
+	}
+	if _, err := NewHistogramRange(1, 1, 2); err == nil {
+		t.Fatal("empty range accepted")
+	}
+}
@@ -0,0 +1,106 @@
+// Package stats computes descriptive statistics over slices of any
+// integer or floating-point type.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Number is the set of types the statistics accept.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ErrEmpty is returned for statistics that are undefined without data,
+// like the mean of an empty slice or the variance of a single value.
+var ErrEmpty = errors.New("stats: not enough data")
+
+// Sum returns the sum of xs as a float64, compensating for rounding so
+// that many small values are not lost next to a large one.
+func Sum[T Number](xs []T) float64 {
+	var sum, c float64 // Kahan-Babuška-Neumaier
+	for _, x := range xs {
+		v := float64(x)
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			c += (sum - t) + v
+		} else {
+			c += (v - t) + sum
+		}
+		sum = t
+	}
+	return sum + c
+}
+
+// Mean returns the arithmetic mean of xs.
+func Mean[T Number](xs []T) (float64, error) {
+	a := Accumulate(xs)
+	return a.Mean()
+}
+
+// Variance returns the sample variance of xs, dividing by n-1.
+func Variance[T Number](xs []T) (float64, error) {
+	a := Accumulate(xs)
+	return a.Variance()
+}
+
+// PopVariance returns the population variance of xs, dividing by n.
+func PopVariance[T Number](xs []T) (float64, error) {
+	a := Accumulate(xs)
+	return a.PopVariance()
+}
+
+// StdDev returns the sample standard deviation of xs.
+func StdDev[T Number](xs []T) (float64, error) {
+	a := Accumulate(xs)
+	return a.StdDev()
+}
+
+// Median returns the middle value of xs, or the mean of the two middle
+// values when len(xs) is even. xs is not modified.
+func Median[T Number](xs []T) (float64, error) {
+	return Percentile(xs, 50)
+}
+
+// Percentile returns the p-th percentile of xs for p in [0, 100],
+// interpolating linearly between the closest ranks. xs is not modified.
+func Percentile[T Number](xs []T, p float64) (float64, error) {
+	ps, err := Percentiles(xs, p)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return ps[0], nil
+}
+
+// Percentiles is like Percentile for several p at once, sorting a copy
+// of xs only once.
+func Percentiles[T Number](xs []T, ps ...float64) ([]float64, error) {
+	if len(xs) == 0 {
+		return nil, ErrEmpty
+	}
+	for _, p := range ps {
+		if !(0 <= p && p <= 100) {
+			return nil, fmt.Errorf("stats: percentile %g out of range [0, 100]", p)
+		}
+	}
+
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+
+	out := make([]float64, len(ps))
+	for i, p := range ps {
+		rank := p / 100 * float64(len(sorted)-1)
+		lo := int(rank)
+		v := float64(sorted[lo])
+		if frac := rank - float64(lo); frac > 0 {
+			v += frac * (float64(sorted[lo+1]) - v)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDescriptive(t *testing.T) {
+	xs := []int{2, 4, 4, 4, 5, 5, 7, 9}
+
+	mean, err := Mean(xs)
+	if err != nil || mean != 5 {
+		t.Fatalf("Mean = %g, %v, want 5", mean, err)
+	}
+	pv, _ := PopVariance(xs)
+	v, _ := Variance(xs)
+	sd, _ := StdDev(xs)
+	if pv != 4 || !closeTo(v, 32.0/7, 1e-12) || !closeTo(sd, math.Sqrt(32.0/7), 1e-12) {
+		t.Fatalf("PopVariance, Variance, StdDev = %g, %g, %g", pv, v, sd)
+	}
+	if m, _ := Median(xs); m != 4.5 {
+		t.Fatalf("Median = %g, want 4.5", m)
+	}
+}
+
+func TestGenericTypes(t *testing.T) {
+	type celsius float32
+	if m, _ := Mean([]celsius{20.5, 21.5}); m != 21 {
+		t.Fatalf("Mean(celsius) = %g", m)
+	}
+	if m, _ := Mean([]uint8{255, 255, 255}); m != 255 {
+		t.Fatalf("Mean(uint8) = %g, want 255 without overflow", m)
+	}
+	if s := Sum([]int64{math.MaxInt64, math.MaxInt64}); s != 2*math.MaxInt64 {
+		t.Fatalf("Sum(int64) = %g", s)
+	}
+}
+
+func TestSumCompensated(t *testing.T) {
+	xs := []float64{1e16, 1, -1e16}
+	for i := 0; i < 10; i++ {
+		xs = append(xs, 0.1)
+	}
+	if s := Sum(xs); !closeTo(s, 2, 1e-12) {
+		t.Fatalf("Sum = %.17g, want 2", s)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	xs := []float64{15, 20, 35, 40, 50}
+	orig := slices.Clone(xs)
+	slices.Reverse(xs)
+
+	got, err := Percentiles(xs, 0, 25, 40, 50, 100)
+	want := []float64{15, 20, 29, 35, 50}
+	if err != nil || !slices.Equal(got, want) {
+		t.Fatalf("Percentiles = %v, %v, want %v", got, err, want)
+	}
+	if slices.Reverse(xs); !slices.Equal(xs, orig) {
+		t.Fatalf("Percentiles modified its input: %v", xs)
+	}
+
+	for _, p := range []float64{-1, 100.5, math.NaN()} {
+		if _, err := Percentile(xs, p); err == nil {
+			t.Fatalf("Percentile(%g) succeeded", p)
+		}
+	}
+	if m, err := Median([]int{7}); err != nil || m != 7 {
+		t.Fatalf("Median([7]) = %g, %v", m, err)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	var none []float64
+	for name, f := range map[string]func([]float64) (float64, error){
+		"Mean": Mean[float64], "Variance": Variance[float64], "PopVariance": PopVariance[float64],
+		"StdDev": StdDev[float64], "Median": Median[float64],
+	} {
+		if v, err := f(none); !errors.Is(err, ErrEmpty) || !math.IsNaN(v) {
+			t.Fatalf("%s(empty) = %g, %v, want NaN, ErrEmpty", name, v, err)
+		}
+	}
+	if _, err := Variance([]int{1}); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Variance of one value: %v, want ErrEmpty", err)
+	}
+	if Sum(none) != 0 {
+		t.Fatal("Sum(empty) != 0")
+	}
+}
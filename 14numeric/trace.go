@@ -0,0 +1,64 @@
+package numeric
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Step describes one iteration of a numerical routine.
+type Step struct {
+	Iteration int     // starts at 1
+	Estimate  float64 // estimate after the step
+	Delta     float64 // size of the step
+}
+
+// Tracer observes the iterations of a numerical routine. op names the
+// routine, e.g. "sqrt" or "brent".
+type Tracer interface {
+	Trace(op string, s Step)
+}
+
+// TracerFunc adapts an ordinary function to the Tracer interface.
+type TracerFunc func(op string, s Step)
+
+func (f TracerFunc) Trace(op string, s Step) {
+	f(op, s)
+}
+
+// Trace passes s to o.Tracer, if there is one. Routines call it once per
+// iteration.
+func (o Options) Trace(op string, s Step) {
+	if o.Tracer != nil {
+		o.Tracer.Trace(op, s)
+	}
+}
+
+// NewWriterTracer returns a Tracer that prints one line per step to w.
+func NewWriterTracer(w io.Writer) Tracer {
+	return TracerFunc(func(op string, s Step) {
+		fmt.Fprintf(w, "%s: iteration %d: estimate %v, delta %v\n", op, s.Iteration, s.Estimate, s.Delta)
+	})
+}
+
+// Recorder is a Tracer that keeps every step, e.g. to check convergence
+// in tests. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	steps []Step
+}
+
+func (r *Recorder) Trace(op string, s Step) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.steps = append(r.steps, s)
+}
+
+// Steps returns a copy of the steps recorded so far.
+func (r *Recorder) Steps() []Step {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Step(nil), r.steps...)
+}
@@ -0,0 +1,42 @@
+package numeric
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSqrtTrace(t *testing.T) {
+	var rec Recorder
+	opts := DefaultOptions
+	opts.Tracer = &rec
+
+	res, err := SqrtWith(1e10, opts)
+	steps := rec.Steps()
+	if err != nil || len(steps) != res.Iterations {
+		t.Fatalf("SqrtWith(1e10) traced %d steps in %d iterations, %v", len(steps), res.Iterations, err)
+	}
+
+	for i, s := range steps {
+		if s.Iteration != i+1 {
+			t.Fatalf("step %d has Iteration %d, want %d", i, s.Iteration, i+1)
+		}
+		// Newton's method converges quadratically, so the steps shrink
+		if i > 0 && s.Delta > steps[i-1].Delta {
+			t.Fatalf("step %d: delta %g grew from %g", i+1, s.Delta, steps[i-1].Delta)
+		}
+	}
+	if last := steps[len(steps)-1]; last.Estimate != res.Value || last.Delta != res.Delta {
+		t.Fatalf("last step %+v does not match result %+v", last, res)
+	}
+}
+
+func TestWriterTracer(t *testing.T) {
+	var b strings.Builder
+	opts := DefaultOptions
+	opts.Tracer = NewWriterTracer(&b)
+
+	SqrtWith(4, opts)
+	if !strings.HasPrefix(b.String(), "sqrt: iteration 1: estimate 2, delta 0\n") {
+		t.Fatalf("trace output = %q, want it to start with the first sqrt step", b.String())
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 / 4", 2.5},
+		{"7 % 3", 1},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"2 ^ -1", 0.5},
+		{"--3", 3},
+		{"1.5e3 + .5", 1500.5},
+		{"sqrt(2) * sqrt(2)", 2},
+		{"abs(-3.5)", 3.5},
+		{"hypot(3, 4)", 5},
+		{"pow(2, 10)", 1024},
+		{"sqrt(hypot(3, 4) + 4)", 3},
+		{"pi", math.Pi},
+	}
+
+	for _, tt := range tests {
+		got, err := newEnv().eval(tt.src)
+		if err != nil || math.Abs(got-tt.want) > 1e-12 {
+			t.Fatalf("eval(%q) = %v, %v, want %v", tt.src, got, err, tt.want)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	e := newEnv()
+	for _, src := range []string{"x = 3", "y = x * 2", "x = x + y"} {
+		if _, err := e.eval(src); err != nil {
+			t.Fatalf("eval(%q) error = %v", src, err)
+		}
+	}
+
+	if got, err := e.eval("x"); got != 9 || err != nil {
+		t.Fatalf(`eval("x") = %v, %v, want 9`, got, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+		msg string
+	}{
+		{"1 + * 2", 4, `unexpected "*"`},
+		{"(1 + 2", 6, `expected ")", found end of input`},
+		{"1 2", 2, "unexpected number 2"},
+		{"3 $ 4", 2, `unexpected character '$'`},
+		{"foo + 1", 0, `undefined variable "foo"`},
+		{"1 + bar(2)", 4, `undefined function "bar"`},
+		{"hypot(1)", 0, "hypot takes 2 arguments, got 1"},
+		{"4 / (2 - 2)", 2, "division by zero"},
+		{"1 + sqrt(-4)", 4, "sqrt(-4): domain error"},
+		{"sqrt = 2", 0, `cannot assign to function "sqrt"`},
+		{"", 0, "unexpected end of input"},
+	}
+
+	for _, tt := range tests {
+		_, err := newEnv().eval(tt.src)
+
+		var calcErr *Error
+		if !errors.As(err, &calcErr) || calcErr.Pos != tt.pos || calcErr.Msg != tt.msg {
+			t.Fatalf("eval(%q) error = %#v, want %q at %d", tt.src, err, tt.msg, tt.pos)
+		}
+	}
+}
+
+func TestREPL(t *testing.T) {
+	in := "r = 2\narea = pi * r ^ 2\n1 +\nvars\nquit\n99\n"
+	var stdout, stderr bytes.Buffer
+
+	code := run(nil, strings.NewReader(in), &stdout, &stderr, false)
+	if code != 1 {
+		t.Fatalf("run = %d, want 1 after a failed line", code)
+	}
+
+	want := "2\n12.566370614359172\narea = 12.566370614359172\ne = 2.718281828459045\npi = 3.141592653589793\nr = 2\n"
+	if stdout.String() != want {
+		t.Fatalf("stdout =\n%s\nwant:\n%s", &stdout, want)
+	}
+	if want := "  1 +\n     ^\nerror: col 4: unexpected end of input\n"; stderr.String() != want {
+		t.Fatalf("stderr = %q, want %q", &stderr, want)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"1 + 1", "hypot(5, 12)"}, strings.NewReader(""), &stdout, &stderr, false)
+	if code != 0 || stdout.String() != "2\n13\n" || stderr.Len() != 0 {
+		t.Fatalf("run = %d, %q, %q, want 0, \"2\\n13\\n\", \"\"", code, &stdout, &stderr)
+	}
+}
+
+func TestArgsLeadingMinus(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	// ^ binds tighter than unary minus, and nothing is parsed as a flag
+	code := run([]string{"-2^2", "-h"}, strings.NewReader(""), &stdout, &stderr, false)
+	if code != 1 || stdout.String() != "-4\n" || !strings.Contains(stderr.String(), `"h"`) {
+		t.Fatalf("run = %d, %q, %q, want 1, \"-4\\n\" and an unknown variable h", code, &stdout, &stderr)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run(nil, strings.NewReader("help\n"), &stdout, &stderr, false)
+	if code != 0 || stdout.String() != usage || stderr.Len() != 0 {
+		t.Fatalf("run = %d, %q, %q, want the usage text", code, &stdout, &stderr)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"example.com/numeric"
+)
+
+// function is a builtin the expressions can call.
+type function struct {
+	arity int
+	call  func(args []float64) (float64, error)
+}
+
+// functions evaluate with the project's own routines where there is
+// one: numeric.Sqrt and the Abs method of numeric.Float (MyFloat).
+var functions = map[string]function{
+	"sqrt": {1, func(a []float64) (float64, error) {
+		res, err := numeric.SqrtWith(a[0], numeric.DefaultOptions)
+		return res.Value, err
+	}},
+	"abs": {1, func(a []float64) (float64, error) {
+		return numeric.Float(a[0]).Abs(), nil
+	}},
+	"hypot": {2, func(a []float64) (float64, error) {
+		return math.Hypot(a[0], a[1]), nil
+	}},
+	"pow": {2, func(a []float64) (float64, error) {
+		return math.Pow(a[0], a[1]), nil
+	}},
+}
+
+// env holds the variables of a calculator session.
+type env struct {
+	vars map[string]float64
+}
+
+func newEnv() *env {
+	return &env{vars: map[string]float64{
+		"pi": math.Pi,
+		"e":  math.E,
+	}}
+}
+
+// names returns the sorted names of the variables.
+func (e *env) names() []string {
+	names := make([]string, 0, len(e.vars))
+	for name := range e.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// eval parses and evaluates one statement. Assignments store the value
+// in the environment and also return it.
+func (e *env) eval(src string) (float64, error) {
+	n, err := parse(src)
+	if err != nil {
+		return 0, err
+	}
+	return e.evalNode(n)
+}
+
+func (e *env) evalNode(n node) (float64, error) {
+	switch n := n.(type) {
+	case *numberNode:
+		return n.val, nil
+
+	case *varNode:
+		v, ok := e.vars[n.name]
+		if !ok {
+			return 0, errorf(n.pos, "undefined variable %q", n.name)
+		}
+		return v, nil
+
+	case *assignNode:
+		if _, ok := functions[n.name]; ok {
+			return 0, errorf(n.pos, "cannot assign to function %q", n.name)
+		}
+		v, err := e.evalNode(n.x)
+		if err != nil {
+			return 0, err
+		}
+		e.vars[n.name] = v
+		return v, nil
+
+	case *unaryNode:
+		x, err := e.evalNode(n.x)
+		if err != nil {
+			return 0, err
+		}
+		if n.op == "-" {
+			return -x, nil
+		}
+		return x, nil
+
+	case *binaryNode:
+		return e.evalBinary(n)
+
+	case *callNode:
+		return e.evalCall(n)
+	}
+
+	return 0, errorf(n.position(), "cannot evaluate %T", n)
+}
+
+func (e *env) evalBinary(n *binaryNode) (float64, error) {
+	x, err := e.evalNode(n.x)
+	if err != nil {
+		return 0, err
+	}
+	y, err := e.evalNode(n.y)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		if y == 0 {
+			return 0, errorf(n.pos, "division by zero")
+		}
+		return x / y, nil
+	case "%":
+		if y == 0 {
+			return 0, errorf(n.pos, "division by zero")
+		}
+		return math.Mod(x, y), nil
+	case "^":
+		return math.Pow(x, y), nil
+	}
+
+	return 0, errorf(n.pos, "unknown operator %q", n.op)
+}
+
+func (e *env) evalCall(n *callNode) (float64, error) {
+	fn, ok := functions[n.name]
+	if !ok {
+		return 0, errorf(n.pos, "undefined function %q", n.name)
+	}
+	if len(n.args) != fn.arity {
+		return 0, errorf(n.pos, "%s takes %d arguments, got %d", n.name, fn.arity, len(n.args))
+	}
+
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		v, err := e.evalNode(arg)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	v, err := fn.call(args)
+	if err != nil {
+		// e.g. a numeric.Error: "sqrt(-1): domain error"
+		return 0, errorf(n.pos, "%v", err)
+	}
+
+	return v, nil
+}
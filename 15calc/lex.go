@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp // one of + - * / % ^ ( ) , =
+)
+
+// token is a lexeme of an expression; pos is its byte offset in the input.
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokNumber:
+		return fmt.Sprintf("number %s", t.text)
+	case tokIdent:
+		return fmt.Sprintf("name %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// Error is a lex, parse or evaluation error at a byte offset of the input.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("col %d: %s", e.Pos+1, e.Msg)
+}
+
+func errorf(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// lex splits src into tokens, ending with a tokEOF.
+func lex(src string) ([]token, error) {
+	var toks []token
+
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case r >= '0' && r <= '9' || r == '.':
+			j := scanNumber(src, i)
+			n, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, errorf(i, "bad number %q", src[i:j])
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[i:j], num: n, pos: i})
+			i = j
+
+		case r == '_' || unicode.IsLetter(r):
+			j := i + size
+			for j < len(src) {
+				r, size := utf8.DecodeRuneInString(src[j:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += size
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[i:j], pos: i})
+			i = j
+
+		case r < utf8.RuneSelf && isOp(byte(r)):
+			toks = append(toks, token{kind: tokOp, text: src[i : i+1], pos: i})
+			i++
+
+		default:
+			return nil, errorf(i, "unexpected character %q", r)
+		}
+	}
+
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// scanNumber returns the end of the number starting at src[i]:
+// digits, an optional fraction and an optional exponent.
+func scanNumber(src string, i int) int {
+	digits := func(i int) int {
+		for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+			i++
+		}
+		return i
+	}
+
+	i = digits(i)
+	if i < len(src) && src[i] == '.' {
+		i = digits(i + 1)
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		j := i + 1
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			j++
+		}
+		if k := digits(j); k > j {
+			i = k
+		}
+	}
+
+	return i
+}
+
+func isOp(c byte) bool {
+	switch c {
+	case '+', '-', '*', '/', '%', '^', '(', ')', ',', '=':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	//only show a prompt when a person is typing, not for piped input
+	fi, err := os.Stdin.Stat()
+	interactive := err == nil && fi.Mode()&os.ModeCharDevice != 0
+
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, interactive))
+}
+
+// usage is printed by the help command. calc takes no flags: every
+// argument is an expression, so that "-2^2" is not mistaken for one.
+const usage = `usage: calc [expression ...]
+
+Prints the value of each expression, or reads statements from
+standard input, one per line, when there are none.
+
+  operators:  + - * / % ^ and parentheses
+  functions:  sqrt(x) abs(x) hypot(x, y) pow(x, y)
+  variables:  name = expression; pi and e are predefined
+  commands:   vars, help, quit (REPL only)
+`
+
+// run evaluates the expressions in args, or else runs a REPL on stdin,
+// and returns the exit code: 1 if any expression failed.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, interactive bool) int {
+	e := newEnv()
+	code := 0
+
+	if len(args) > 0 {
+		for _, src := range args {
+			if !evalLine(e, src, stdout, stderr) {
+				code = 1
+			}
+		}
+		return code
+	}
+
+	sc := bufio.NewScanner(stdin)
+	for {
+		if interactive {
+			fmt.Fprint(stdout, "> ")
+		}
+		if !sc.Scan() {
+			break
+		}
+
+		src := strings.TrimSpace(sc.Text())
+		switch src {
+		case "":
+			continue
+		case "quit", "exit":
+			return code
+		case "help":
+			fmt.Fprint(stdout, usage)
+			continue
+		case "vars":
+			for _, name := range e.names() {
+				fmt.Fprintf(stdout, "%s = %s\n", name, format(e.vars[name]))
+			}
+			continue
+		}
+
+		if !evalLine(e, src, stdout, stderr) {
+			code = 1
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return code
+}
+
+// evalLine evaluates src and prints its value, or the error with a
+// caret under the place it happened. It reports whether it succeeded.
+func evalLine(e *env, src string, stdout, stderr io.Writer) bool {
+	v, err := e.eval(src)
+	if err == nil {
+		fmt.Fprintln(stdout, format(v))
+		return true
+	}
+
+	var calcErr *Error
+	if errors.As(err, &calcErr) {
+		fmt.Fprintf(stderr, "  %s\n  %s^\n", src, strings.Repeat(" ", calcErr.Pos))
+	}
+	fmt.Fprintf(stderr, "error: %v\n", err)
+	return false
+}
+
+// format prints v with the fewest digits that read back as v.
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,236 @@
+package main
+
+// node is an expression tree node; pos is the byte offset of the
+// token it was parsed from, used to place evaluation errors.
+type node interface {
+	position() int
+}
+
+type (
+	numberNode struct {
+		pos int
+		val float64
+	}
+	varNode struct {
+		pos  int
+		name string
+	}
+	unaryNode struct {
+		pos int
+		op  string
+		x   node
+	}
+	binaryNode struct {
+		pos  int
+		op   string
+		x, y node
+	}
+	callNode struct {
+		pos  int
+		name string
+		args []node
+	}
+	assignNode struct {
+		pos  int
+		name string
+		x    node
+	}
+)
+
+func (n *numberNode) position() int { return n.pos }
+func (n *varNode) position() int    { return n.pos }
+func (n *unaryNode) position() int  { return n.pos }
+func (n *binaryNode) position() int { return n.pos }
+func (n *callNode) position() int   { return n.pos }
+func (n *assignNode) position() int { return n.pos }
+
+// parse parses one statement:
+//
+//	stmt    = name "=" expr | expr
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | name | name "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// so ^ binds tighter than unary minus and is right associative:
+// -2^2 is -4 and 2^3^2 is 512.
+func parse(src string) (node, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{toks: toks}
+	n, err := p.stmt()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorf(t.pos, "unexpected %v", t)
+	}
+
+	return n, nil
+}
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators ops.
+func (p *parser) accept(ops ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokOp {
+		return t, false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return p.next(), true
+		}
+	}
+	return t, false
+}
+
+func (p *parser) expect(op string) error {
+	if t, ok := p.accept(op); !ok {
+		return errorf(t.pos, "expected %q, found %v", op, t)
+	}
+	return nil
+}
+
+func (p *parser) stmt() (node, error) {
+	if p.peek().kind == tokIdent && p.toks[p.i+1].kind == tokOp && p.toks[p.i+1].text == "=" {
+		name := p.next()
+		p.next()
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return &assignNode{pos: name.pos, name: name.text, x: x}, nil
+	}
+	return p.expr()
+}
+
+func (p *parser) expr() (node, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{pos: op.pos, op: op.text, x: x, y: y}
+	}
+}
+
+func (p *parser) term() (node, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.accept("*", "/", "%")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{pos: op.pos, op: op.text, x: x, y: y}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if op, ok := p.accept("+", "-"); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: op.pos, op: op.text, x: x}, nil
+	}
+	return p.power()
+}
+
+func (p *parser) power() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	if op, ok := p.accept("^"); ok {
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{pos: op.pos, op: op.text, x: x, y: y}, nil
+	}
+	return x, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+
+	switch {
+	case t.kind == tokNumber:
+		return &numberNode{pos: t.pos, val: t.num}, nil
+
+	case t.kind == tokIdent:
+		if _, ok := p.accept("("); !ok {
+			return &varNode{pos: t.pos, name: t.text}, nil
+		}
+
+		call := &callNode{pos: t.pos, name: t.text}
+		if _, ok := p.accept(")"); ok {
+			return call, nil
+		}
+		for {
+			arg, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return call, nil
+
+	case t.kind == tokOp && t.text == "(":
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+
+	return nil, errorf(t.pos, "unexpected %v", t)
+}
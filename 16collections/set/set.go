@@ -0,0 +1,260 @@
+// Package set implements a generic set of comparable values.
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Set is an unordered collection of distinct values of type T with O(1)
+// membership tests. The zero value is an empty set ready to use. A Set
+// is not safe for concurrent use.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// New returns a set holding items.
+func New[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// Len returns the number of elements in s.
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Add adds items to s.
+func (s *Set[T]) Add(items ...T) {
+	if s.m == nil {
+		s.m = make(map[T]struct{}, len(items))
+	}
+	for _, x := range items {
+		s.m[x] = struct{}{}
+	}
+}
+
+// Remove removes items from s; items not in s are ignored.
+func (s *Set[T]) Remove(items ...T) {
+	for _, x := range items {
+		delete(s.m, x)
+	}
+}
+
+// Has reports whether x is in s.
+func (s *Set[T]) Has(x T) bool {
+	_, ok := s.m[x]
+	return ok
+}
+
+// Clear removes all elements from s.
+func (s *Set[T]) Clear() {
+	clear(s.m)
+}
+
+// Clone returns a copy of s.
+func (s *Set[T]) Clone() *Set[T] {
+	c := &Set[T]{m: make(map[T]struct{}, len(s.m))}
+	for x := range s.m {
+		c.m[x] = struct{}{}
+	}
+	return c
+}
+
+// Union returns a new set of the elements in s, t or both.
+func (s *Set[T]) Union(t *Set[T]) *Set[T] {
+	u := s.Clone()
+	for x := range t.m {
+		u.m[x] = struct{}{}
+	}
+	return u
+}
+
+// Intersection returns a new set of the elements in both s and t.
+func (s *Set[T]) Intersection(t *Set[T]) *Set[T] {
+	small, large := s, t
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	u := &Set[T]{m: make(map[T]struct{})}
+	for x := range small.m {
+		if large.Has(x) {
+			u.m[x] = struct{}{}
+		}
+	}
+	return u
+}
+
+// Difference returns a new set of the elements in s but not in t.
+func (s *Set[T]) Difference(t *Set[T]) *Set[T] {
+	u := &Set[T]{m: make(map[T]struct{})}
+	for x := range s.m {
+		if !t.Has(x) {
+			u.m[x] = struct{}{}
+		}
+	}
+	return u
+}
+
+// SymmetricDifference returns a new set of the elements in exactly one
+// of s and t.
+func (s *Set[T]) SymmetricDifference(t *Set[T]) *Set[T] {
+	u := s.Difference(t)
+	for x := range t.m {
+		if !s.Has(x) {
+			u.m[x] = struct{}{}
+		}
+	}
+	return u
+}
+
+// IsSubset reports whether every element of s is in t.
+func (s *Set[T]) IsSubset(t *Set[T]) bool {
+	if s.Len() > t.Len() {
+		return false
+	}
+	for x := range s.m {
+		if !t.Has(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every element of t is in s.
+func (s *Set[T]) IsSuperset(t *Set[T]) bool {
+	return t.IsSubset(s)
+}
+
+// IsDisjoint reports whether s and t have no elements in common.
+func (s *Set[T]) IsDisjoint(t *Set[T]) bool {
+	return s.Intersection(t).Len() == 0
+}
+
+// Equal reports whether s and t hold the same elements.
+func (s *Set[T]) Equal(t *Set[T]) bool {
+	return s.Len() == t.Len() && s.IsSubset(t)
+}
+
+// All returns an iterator over the elements of s in no particular
+// order; see the collections package doc for the iterator shape. s must
+// not be modified during the iteration.
+func (s *Set[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for x := range s.m {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the elements of s in no particular order. Use Sorted
+// or SortedFunc for a deterministic order.
+func (s *Set[T]) Slice() []T {
+	out := make([]T, 0, len(s.m))
+	for x := range s.m {
+		out = append(out, x)
+	}
+	return out
+}
+
+// Sorted returns the elements of s in increasing order.
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	out := s.Slice()
+	slices.Sort(out)
+	return out
+}
+
+// SortedFunc returns the elements of s ordered by compare.
+func SortedFunc[T comparable](s *Set[T], compare func(a, b T) int) []T {
+	out := s.Slice()
+	slices.SortFunc(out, compare)
+	return out
+}
+
+// String formats s like {a b c}, with the elements ordered as by
+// MarshalJSON. Like MarshalJSON it has a value receiver, so that a Set
+// held by value in a struct formats as a set too.
+func (s Set[T]) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, x := range s.ordered() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, x)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// MarshalJSON encodes s as a JSON array. The output is deterministic:
+// elements of integer, float and string kinds are sorted by value, and
+// any others by their JSON encoding.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ordered())
+}
+
+// UnmarshalJSON replaces the contents of s with the elements of a JSON
+// array; duplicates are dropped. null leaves s unchanged.
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		return nil
+	}
+	s.Clear()
+	s.Add(items...)
+	return nil
+}
+
+// ordered returns the elements of s in the deterministic order used by
+// String and MarshalJSON. T is only comparable, so the order is found
+// through reflection on the static type; for an interface T the dynamic
+// types may differ, so those are ordered by encoding.
+func (s *Set[T]) ordered() []T {
+	out := s.Slice()
+	if len(out) < 2 {
+		return out
+	}
+
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		slices.SortFunc(out, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		})
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		slices.SortFunc(out, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		})
+	case reflect.Float32, reflect.Float64:
+		slices.SortFunc(out, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		})
+	case reflect.String:
+		slices.SortFunc(out, func(a, b T) int {
+			return strings.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		})
+	default:
+		// structs, pointers, interfaces...: order by encoding, and
+		// fall back to %v for values JSON cannot encode
+		keys := make(map[T]string, len(out))
+		for _, x := range out {
+			if b, err := json.Marshal(x); err == nil {
+				keys[x] = string(b)
+			} else {
+				keys[x] = fmt.Sprint(x)
+			}
+		}
+		slices.SortFunc(out, func(a, b T) int { return strings.Compare(keys[a], keys[b]) })
+	}
+	return out
+}
@@ -0,0 +1,182 @@
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBasics(t *testing.T) {
+	var s Set[string] // the zero value is usable
+	if s.Has("a") || s.Len() != 0 {
+		t.Fatal("zero Set is not empty")
+	}
+	s.Remove("a")
+	s.Add("a", "b", "a")
+	if !s.Has("a") || !s.Has("b") || s.Has("c") || s.Len() != 2 {
+		t.Fatalf("s = %v", &s)
+	}
+
+	c := s.Clone()
+	s.Remove("a", "z")
+	if s.Has("a") || !c.Has("a") {
+		t.Fatal("Clone shares storage with the original")
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Fatal("Clear left elements")
+	}
+}
+
+func TestAlgebra(t *testing.T) {
+	a, b := New(1, 2, 3, 4), New(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Intersection reversed", b.Intersection(a), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference reversed", b.Difference(a), []int{5}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 2, 5}},
+		{"Union with empty", a.Union(New[int]()), []int{1, 2, 3, 4}},
+		{"Intersection with zero", a.Intersection(&Set[int]{}), []int{}},
+	}
+	for _, tt := range tests {
+		if got := Sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Fatalf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := Sorted(a); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("operations modified a: %v", got)
+	}
+}
+
+func TestRelations(t *testing.T) {
+	small, big, other := New("x"), New("x", "y"), New("z")
+	var empty Set[string]
+
+	checks := []struct {
+		name      string
+		got, want bool
+	}{
+		{"small ⊆ big", small.IsSubset(big), true},
+		{"big ⊆ small", big.IsSubset(small), false},
+		{"big ⊇ small", big.IsSuperset(small), true},
+		{"∅ ⊆ small", empty.IsSubset(small), true},
+		{"small ⊆ small", small.IsSubset(small), true},
+		{"disjoint", big.IsDisjoint(other), true},
+		{"not disjoint", big.IsDisjoint(small), false},
+		{"equal", big.Equal(New("y", "x")), true},
+		{"not equal", big.Equal(New("x", "z")), false},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Fatalf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestSortedAndAll(t *testing.T) {
+	s := New("pear", "apple", "fig")
+	if got := Sorted(s); !slices.Equal(got, []string{"apple", "fig", "pear"}) {
+		t.Fatalf("Sorted = %v", got)
+	}
+	byLen := SortedFunc(s, func(a, b string) int { return len(a) - len(b) })
+	if !slices.Equal(byLen, []string{"fig", "pear", "apple"}) {
+		t.Fatalf("SortedFunc = %v", byLen)
+	}
+
+	n := 0
+	s.All()(func(string) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Fatalf("All kept going after yield returned false: %d calls", n)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type point struct{ X, Y int }
+	type doc struct {
+		IDs    *Set[int]    `json:"ids"`
+		Tags   Set[string]  `json:"tags"`
+		Points *Set[point]  `json:"points"`
+		Mixed  *Set[any]    `json:"mixed"`
+		Empty  *Set[uint16] `json:"empty"`
+	}
+
+	d := doc{
+		IDs:    New(10, 9, -3, 100),
+		Points: New(point{2, 1}, point{1, 2}),
+		Mixed:  New[any]("b", 2, "a", 1.5),
+		Empty:  New[uint16](),
+	}
+	d.Tags.Add("go", "sets", "generic")
+
+	want := `{"ids":[-3,9,10,100],"tags":["generic","go","sets"],` +
+		`"points":[{"X":1,"Y":2},{"X":2,"Y":1}],"mixed":["a","b",1.5,2],"empty":[]}`
+	for i := 0; i < 5; i++ { // map order varies between runs
+		out, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != want {
+			t.Fatalf("Marshal =\n%s\nwant\n%s", out, want)
+		}
+	}
+
+	var back doc
+	if err := json.Unmarshal([]byte(want), &back); err != nil {
+		t.Fatal(err)
+	}
+	if !back.IDs.Equal(d.IDs) || !back.Tags.Equal(&d.Tags) || !back.Points.Equal(d.Points) || back.Empty.Len() != 0 {
+		t.Fatalf("round trip = %+v", back)
+	}
+
+	s := New(1, 2)
+	if err := json.Unmarshal([]byte(`[3, 3, 4]`), s); err != nil || !s.Equal(New(3, 4)) {
+		t.Fatalf("Unmarshal into a set = %v, %v", s, err)
+	}
+	if err := json.Unmarshal([]byte(`null`), s); err != nil || !s.Equal(New(3, 4)) {
+		t.Fatalf("Unmarshal null = %v, %v", s, err)
+	}
+	if err := json.Unmarshal([]byte(`["x"]`), s); err == nil {
+		t.Fatal("Unmarshal of strings into Set[int] succeeded")
+	}
+}
+
+func TestString(t *testing.T) {
+	if got := New(3, 1, 2).String(); got != "{1 2 3}" {
+		t.Fatalf("String = %s", got)
+	}
+	var byValue Set[int]
+	byValue.Add(2, 1)
+	if got := fmt.Sprint(byValue); got != "{1 2}" {
+		t.Fatalf("Sprint of a Set value = %s", got)
+	}
+	if got := New[string]().String(); got != "{}" {
+		t.Fatalf("String of empty = %s", got)
+	}
+	if got := New(strings.Fields("b a")...).String(); got != "{a b}" {
+		t.Fatalf("String = %s", got)
+	}
+}
+
+func BenchmarkHas(b *testing.B) {
+	s := New[int]()
+	for i := 0; i < 1024; i++ {
+		s.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Has(i & 2047)
+	}
+}
@@ -0,0 +1,206 @@
+// Package collections holds generic algorithms over slices in the style
+// of the standard slices package, generalizing Index from the generics
+// lesson (11generic). The subpackages provide generic containers.
+//
+// Unless a function says otherwise it never modifies its input, and it
+// returns nil for a nil input so that nil-ness survives a pipeline.
+//
+// The containers' iterators are plain funcs of the form
+// func(yield func(T) bool), the shape of a Go 1.23 iter.Seq. The module
+// stays on Go 1.21, where they are called with a yield func, and newer
+// toolchains can range over them directly.
+package collections
+
+import "cmp"
+
+// Map returns the results of applying f to each element of s.
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	if s == nil {
+		return nil
+	}
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// Filter returns a new slice holding the elements of s for which keep
+// returns true, in order. To filter in place without allocating use
+// slices.DeleteFunc.
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	if s == nil {
+		return nil
+	}
+	out := S{}
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value, calling f(acc, v) for each element
+// in order starting with acc = init.
+func Reduce[S ~[]E, E, A any](s S, init A, f func(A, E) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// GroupBy returns the elements of s grouped by key, each group in the
+// order the elements appear in s.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Partition splits s into the elements for which pred returns true and
+// those for which it returns false, keeping their order. Both results
+// share one allocation of len(s) elements.
+func Partition[S ~[]E, E any](s S, pred func(E) bool) (yes, no S) {
+	if s == nil {
+		return nil, nil
+	}
+
+	// yes fills buf from the front and no from the back, then no is
+	// reversed back into order
+	buf := make(S, len(s))
+	i, j := 0, len(s)
+	for _, v := range s {
+		if pred(v) {
+			buf[i] = v
+			i++
+		} else {
+			j--
+			buf[j] = v
+		}
+	}
+	no = buf[i:]
+	for l, r := 0, len(no)-1; l < r; l, r = l+1, r-1 {
+		no[l], no[r] = no[r], no[l]
+	}
+	return buf[:i:i], no
+}
+
+// Chunk splits s into consecutive subslices of n elements; the last may
+// be shorter. The chunks share the backing array of s but their capacity
+// is capped, so appending to one never overwrites the next. Chunk panics
+// if n < 1.
+func Chunk[S ~[]E, E any](s S, n int) []S {
+	if n < 1 {
+		panic("collections: Chunk size must be at least 1")
+	}
+	if s == nil {
+		return nil
+	}
+
+	chunks := make([]S, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// Window returns every run of n consecutive elements of s, sliding one
+// element at a time: len(s)-n+1 windows, or none if s is shorter than n.
+// Like Chunk the windows share the backing array of s with capped
+// capacity. Window panics if n < 1.
+func Window[S ~[]E, E any](s S, n int) []S {
+	if n < 1 {
+		panic("collections: Window size must be at least 1")
+	}
+	if len(s) < n {
+		return nil
+	}
+
+	windows := make([]S, len(s)-n+1)
+	for i := range windows {
+		windows[i] = s[i : i+n : i+n]
+	}
+	return windows
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs up the elements of a and b by index. The result is as long
+// as the shorter of the two.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	if a == nil || b == nil {
+		return nil
+	}
+	out := make([]Pair[A, B], min(len(a), len(b)))
+	for i := range out {
+		out[i] = Pair[A, B]{a[i], b[i]}
+	}
+	return out
+}
+
+// Unzip splits pairs into the slices of their first and second values.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	if pairs == nil {
+		return nil, nil
+	}
+	as, bs := make([]A, len(pairs)), make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.First, p.Second
+	}
+	return as, bs
+}
+
+// Unique returns the distinct elements of s in the order they first
+// appear. Unlike slices.Compact, s need not be sorted.
+func Unique[S ~[]E, E comparable](s S) S {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[E]struct{}, len(s))
+	out := make(S, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// BinarySearch searches for target in s, which must be sorted in
+// increasing order as by slices.Sort (NaNs first). It returns the index
+// of the first element not less than target, and whether that element
+// equals target.
+func BinarySearch[S ~[]E, E cmp.Ordered](s S, target E) (int, bool) {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1) // no overflow
+		if cmp.Less(s[mid], target) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(s) && cmp.Compare(s[lo], target) == 0
+}
+
+// IndexFunc returns the index of the first element of s satisfying f,
+// or -1 if there is none.
+func IndexFunc[S ~[]E, E any](s S, f func(E) bool) int {
+	for i, v := range s {
+		if f(v) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,262 @@
+package collections
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMapFilterReduce(t *testing.T) {
+	words := []string{"go", "generic", "slices", "", "map"}
+
+	lens := Map(words, func(s string) int { return len(s) })
+	if !slices.Equal(lens, []int{2, 7, 6, 0, 3}) {
+		t.Fatalf("Map = %v", lens)
+	}
+
+	long := Filter(words, func(s string) bool { return len(s) > 2 })
+	if !slices.Equal(long, []string{"generic", "slices", "map"}) {
+		t.Fatalf("Filter = %v", long)
+	}
+
+	total := Reduce(lens, 0, func(acc, n int) int { return acc + n })
+	joined := Reduce(words, "", func(acc, s string) string { return acc + s })
+	if total != 18 || joined != "gogenericslicesmap" {
+		t.Fatalf("Reduce = %d, %q", total, joined)
+	}
+}
+
+func TestNilAndEmpty(t *testing.T) {
+	var none []int
+	empty := []int{}
+	even := func(n int) bool { return n%2 == 0 }
+
+	if Map(none, strconv.Itoa) != nil || Map(empty, strconv.Itoa) == nil {
+		t.Fatal("Map does not preserve nil-ness")
+	}
+	if Filter(none, even) != nil || Filter(empty, even) == nil || Filter([]int{1}, even) == nil {
+		t.Fatal("Filter does not preserve nil-ness")
+	}
+	if y, n := Partition(none, even); y != nil || n != nil {
+		t.Fatal("Partition(nil) is not nil")
+	}
+	if Unique(none) != nil || Unique(empty) == nil {
+		t.Fatal("Unique does not preserve nil-ness")
+	}
+	if Chunk(none, 3) != nil || len(Chunk(empty, 3)) != 0 || Window(empty, 1) != nil {
+		t.Fatal("Chunk or Window of nothing is not empty")
+	}
+	if Zip(none, []string{"a"}) != nil || len(GroupBy(none, even)) != 0 {
+		t.Fatal("Zip or GroupBy of nil is not empty")
+	}
+	if i, found := BinarySearch(none, 1); i != 0 || found {
+		t.Fatalf("BinarySearch(nil) = %d, %v", i, found)
+	}
+	if IndexFunc(none, even) != -1 || Reduce(none, 7, func(a, b int) int { return a + b }) != 7 {
+		t.Fatal("IndexFunc or Reduce of nil")
+	}
+}
+
+func TestGroupByPartition(t *testing.T) {
+	xs := []int{5, 2, 8, 3, 6, 1}
+
+	groups := GroupBy(xs, func(n int) string {
+		if n%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	if !slices.Equal(groups["even"], []int{2, 8, 6}) || !slices.Equal(groups["odd"], []int{5, 3, 1}) || len(groups) != 2 {
+		t.Fatalf("GroupBy = %v", groups)
+	}
+
+	big, small := Partition(xs, func(n int) bool { return n > 4 })
+	if !slices.Equal(big, []int{5, 8, 6}) || !slices.Equal(small, []int{2, 3, 1}) {
+		t.Fatalf("Partition = %v, %v", big, small)
+	}
+
+	// appending to yes must not run into no, which shares its array
+	big = append(big, 100)
+	if !slices.Equal(small, []int{2, 3, 1}) {
+		t.Fatalf("append to yes overwrote no: %v", small)
+	}
+}
+
+func TestChunkWindow(t *testing.T) {
+	xs := []int{1, 2, 3, 4, 5}
+
+	chunks := Chunk(xs, 2)
+	if len(chunks) != 3 || !slices.Equal(chunks[2], []int{5}) || !slices.Equal(chunks[0], []int{1, 2}) {
+		t.Fatalf("Chunk = %v", chunks)
+	}
+	chunks[0] = append(chunks[0], 99)
+	if xs[2] != 3 {
+		t.Fatal("appending to a chunk overwrote the next one")
+	}
+
+	windows := Window(xs, 3)
+	if len(windows) != 3 || !slices.Equal(windows[1], []int{2, 3, 4}) {
+		t.Fatalf("Window = %v", windows)
+	}
+	if Window(xs, 6) != nil || len(Window(xs, 5)) != 1 {
+		t.Fatal("Window at the edges")
+	}
+
+	for name, f := range map[string]func(){
+		"Chunk(0)":   func() { Chunk(xs, 0) },
+		"Window(-1)": func() { Window(xs, -1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestZipUnzip(t *testing.T) {
+	pairs := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	if len(pairs) != 2 || pairs[1] != (Pair[string, int]{"b", 2}) {
+		t.Fatalf("Zip = %v", pairs)
+	}
+	as, bs := Unzip(pairs)
+	if !slices.Equal(as, []string{"a", "b"}) || !slices.Equal(bs, []int{1, 2}) {
+		t.Fatalf("Unzip = %v, %v", as, bs)
+	}
+}
+
+func TestUniqueIndexFunc(t *testing.T) {
+	type word string
+	got := Unique([]word{"b", "a", "b", "c", "a"})
+	if !slices.Equal(got, []word{"b", "a", "c"}) {
+		t.Fatalf("Unique = %v", got)
+	}
+	if i := IndexFunc(got, func(w word) bool { return w > "a" }); i != 0 {
+		t.Fatalf("IndexFunc = %d", i)
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	xs := []float64{math.NaN(), -1, 2, 2, 2, 7}
+	tests := []struct {
+		target float64
+		i      int
+		found  bool
+	}{
+		{math.NaN(), 0, true},
+		{math.Inf(-1), 1, false},
+		{-1, 1, true},
+		{2, 2, true},
+		{3, 5, false},
+		{8, 6, false},
+	}
+	for _, tt := range tests {
+		if i, found := BinarySearch(xs, tt.target); i != tt.i || found != tt.found {
+			t.Fatalf("BinarySearch(%g) = %d, %v, want %d, %v", tt.target, i, found, tt.i, tt.found)
+		}
+	}
+}
+
+func FuzzChunkWindow(f *testing.F) {
+	f.Add([]byte(nil), 1)
+	f.Add([]byte{}, 3)
+	f.Add([]byte("abcdefg"), 3)
+	f.Add([]byte("ab"), 5)
+
+	f.Fuzz(func(t *testing.T, s []byte, n int) {
+		if n < 1 || n > 1000 {
+			t.Skip()
+		}
+
+		chunks := Chunk(s, n)
+		if (s == nil) != (chunks == nil) {
+			t.Fatalf("Chunk nil-ness: %v -> %v", s == nil, chunks == nil)
+		}
+		var joined []byte
+		for i, c := range chunks {
+			if len(c) == 0 || len(c) > n || len(c) < n && i != len(chunks)-1 || cap(c) != len(c) {
+				t.Fatalf("chunk %d of %d has len %d cap %d", i, n, len(c), cap(c))
+			}
+			joined = append(joined, c...)
+		}
+		if string(joined) != string(s) {
+			t.Fatalf("chunks join to %q, want %q", joined, s)
+		}
+
+		windows := Window(s, n)
+		if want := max(len(s)-n+1, 0); len(windows) != want {
+			t.Fatalf("%d windows, want %d", len(windows), want)
+		}
+		for i, w := range windows {
+			if string(w) != string(s[i:i+n]) || cap(w) != n {
+				t.Fatalf("window %d = %q", i, w)
+			}
+		}
+	})
+}
+
+func FuzzPartitionFilter(f *testing.F) {
+	f.Add([]byte(nil), byte(0))
+	f.Add([]byte{}, byte(1))
+	f.Add([]byte{1, 200, 3, 150}, byte(100))
+
+	f.Fuzz(func(t *testing.T, s []byte, pivot byte) {
+		pred := func(b byte) bool { return b < pivot }
+		yes, no := Partition(s, pred)
+
+		if !slices.Equal(yes, Filter(s, pred)) {
+			t.Fatalf("Partition yes = %v, Filter = %v", yes, Filter(s, pred))
+		}
+		if !slices.Equal(no, Filter(s, func(b byte) bool { return !pred(b) })) {
+			t.Fatalf("Partition no = %v", no)
+		}
+		if len(yes)+len(no) != len(s) || (s == nil) != (yes == nil) {
+			t.Fatalf("Partition of %v lost elements or nil-ness", s)
+		}
+	})
+}
+
+func FuzzUnique(f *testing.F) {
+	f.Add("")
+	f.Add("abracadabra")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		runes := []rune(s)
+		u := Unique(runes)
+		seen := map[rune]bool{}
+		for _, r := range u {
+			if seen[r] {
+				t.Fatalf("Unique(%q) repeats %q", s, r)
+			}
+			seen[r] = true
+		}
+		for _, r := range runes {
+			if !seen[r] {
+				t.Fatalf("Unique(%q) lost %q", s, r)
+			}
+		}
+		if len(u) > 0 && u[0] != runes[0] {
+			t.Fatalf("Unique(%q) does not start with the first rune", s)
+		}
+	})
+}
+
+func FuzzBinarySearch(f *testing.F) {
+	f.Add([]byte(nil), byte(0))
+	f.Add([]byte{1, 3, 3, 9}, byte(3))
+	f.Add([]byte{1, 3, 3, 9}, byte(10))
+
+	f.Fuzz(func(t *testing.T, s []byte, target byte) {
+		s = slices.Clone(s)
+		slices.Sort(s)
+		i, found := BinarySearch(s, target)
+		wi, wfound := slices.BinarySearch(s, target)
+		if i != wi || found != wfound {
+			t.Fatalf("BinarySearch(%v, %d) = %d, %v, want %d, %v", s, target, i, found, wi, wfound)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package treemap
+
+// The balancing follows Sedgewick's left-leaning red-black trees: a red
+// link joins a node to its parent in the same 2-3 tree node, red links
+// lean left, and every path from the root to a leaf has the same number
+// of black links.
+
+func (m *Map[K, V]) put(h *node[K, V], key K, val V) *node[K, V] {
+	if h == nil {
+		return &node[K, V]{key: key, val: val, red: true, size: 1}
+	}
+
+	switch c := m.cmp(key, h.key); {
+	case c < 0:
+		h.left = m.put(h.left, key, val)
+	case c > 0:
+		h.right = m.put(h.right, key, val)
+	default:
+		h.val = val
+	}
+	return balance(h)
+}
+
+// delete removes key, which must be in the subtree h.
+func (m *Map[K, V]) delete(h *node[K, V], key K) *node[K, V] {
+	if m.cmp(key, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = m.delete(h.left, key)
+		return balance(h)
+	}
+
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+	if m.cmp(key, h.key) == 0 && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+	if m.cmp(key, h.key) == 0 {
+		// replace h by its successor
+		succ := minNode(h.right)
+		h.key, h.val = succ.key, succ.val
+		h.right = deleteMin(h.right)
+	} else {
+		h.right = m.delete(h.right, key)
+	}
+	return balance(h)
+}
+
+func deleteMin[K, V any](h *node[K, V]) *node[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left = deleteMin(h.left)
+	return balance(h)
+}
+
+func minNode[K, V any](h *node[K, V]) *node[K, V] {
+	for h.left != nil {
+		h = h.left
+	}
+	return h
+}
+
+func isRed[K, V any](h *node[K, V]) bool {
+	return h != nil && h.red
+}
+
+func size[K, V any](h *node[K, V]) int {
+	if h == nil {
+		return 0
+	}
+	return h.size
+}
+
+func rotateLeft[K, V any](h *node[K, V]) *node[K, V] {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.red, h.red = h.red, true
+	x.size = h.size
+	h.size = 1 + size(h.left) + size(h.right)
+	return x
+}
+
+func rotateRight[K, V any](h *node[K, V]) *node[K, V] {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.red, h.red = h.red, true
+	x.size = h.size
+	h.size = 1 + size(h.left) + size(h.right)
+	return x
+}
+
+func flipColors[K, V any](h *node[K, V]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+// moveRedLeft makes h.left or one of its children red, assuming h is
+// red and both h.left and h.left.left are black.
+func moveRedLeft[K, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// moveRedRight makes h.right or one of its children red, assuming h is
+// red and both h.right and h.right.left are black.
+func moveRedRight[K, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// balance restores the left-leaning invariants at h on the way back up
+// and recomputes its size.
+func balance[K, V any](h *node[K, V]) *node[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	h.size = 1 + size(h.left) + size(h.right)
+	return h
+}
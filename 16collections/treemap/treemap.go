@@ -0,0 +1,265 @@
+// Package treemap implements a sorted map on a left-leaning red-black
+// tree. Lookups, insertions, deletions, floor/ceiling and rank/select
+// queries are all O(log n), and iteration is in key order.
+package treemap
+
+import "cmp"
+
+// Map is a map from keys of type K to values of type V, ordered by a
+// comparison function. Use New or NewFunc to create one.
+type Map[K, V any] struct {
+	root *node[K, V]
+	cmp  func(a, b K) int
+}
+
+type node[K, V any] struct {
+	key         K
+	val         V
+	left, right *node[K, V]
+	red         bool // color of the link from the parent
+	size        int  // nodes in this subtree, for Rank and Select
+}
+
+// New returns an empty map ordered by cmp.Compare on its keys.
+func New[K cmp.Ordered, V any]() *Map[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty map ordered by compare, which must return a
+// negative number, zero or a positive number when a < b, a == b or
+// a > b, and describe a strict weak order.
+func NewFunc[K, V any](compare func(a, b K) int) *Map[K, V] {
+	return &Map[K, V]{cmp: compare}
+}
+
+// Len returns the number of entries in m.
+func (m *Map[K, V]) Len() int {
+	return size(m.root)
+}
+
+// Get returns the value stored under key, and whether it was found.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Has reports whether m has an entry for key.
+func (m *Map[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put stores val under key, replacing any value already there.
+func (m *Map[K, V]) Put(key K, val V) {
+	m.root = m.put(m.root, key, val)
+	m.root.red = false
+}
+
+// Update stores f(old, found) under key, where old is the value already
+// stored there and found reports whether there was one. It is handy for
+// counters:
+//
+//	m.Update(word, func(n int, _ bool) int { return n + 1 })
+func (m *Map[K, V]) Update(key K, f func(old V, found bool) V) {
+	old, ok := m.Get(key)
+	m.Put(key, f(old, ok))
+}
+
+// Delete removes the entry for key and reports whether there was one.
+func (m *Map[K, V]) Delete(key K) bool {
+	if !m.Has(key) {
+		return false
+	}
+	if !isRed(m.root.left) && !isRed(m.root.right) {
+		m.root.red = true
+	}
+	m.root = m.delete(m.root, key)
+	if m.root != nil {
+		m.root.red = false
+	}
+	return true
+}
+
+// Min returns the entry with the smallest key, or reports false if m is
+// empty.
+func (m *Map[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		return notFound[K, V]()
+	}
+	return entry(minNode(m.root))
+}
+
+// Max returns the entry with the largest key, or reports false if m is
+// empty.
+func (m *Map[K, V]) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		return notFound[K, V]()
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return entry(n)
+}
+
+// Floor returns the entry with the largest key <= key, or reports false
+// if there is none.
+func (m *Map[K, V]) Floor(key K) (K, V, bool) {
+	var best *node[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			best, n = n, n.right
+		default:
+			return entry(n)
+		}
+	}
+	if best == nil {
+		return notFound[K, V]()
+	}
+	return entry(best)
+}
+
+// Ceiling returns the entry with the smallest key >= key, or reports
+// false if there is none.
+func (m *Map[K, V]) Ceiling(key K) (K, V, bool) {
+	var best *node[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			best, n = n, n.left
+		case c > 0:
+			n = n.right
+		default:
+			return entry(n)
+		}
+	}
+	if best == nil {
+		return notFound[K, V]()
+	}
+	return entry(best)
+}
+
+// Rank returns the number of keys in m less than key, which is the
+// index key has, or would have, in key order.
+func (m *Map[K, V]) Rank(key K) int {
+	rank := 0
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += size(n.left) + 1
+			n = n.right
+		default:
+			return rank + size(n.left)
+		}
+	}
+	return rank
+}
+
+// Select returns the entry with index i in key order, the inverse of
+// Rank, or reports false if i is out of range [0, Len()).
+func (m *Map[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= m.Len() {
+		return notFound[K, V]()
+	}
+	n := m.root
+	for {
+		switch l := size(n.left); {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return entry(n)
+		}
+	}
+}
+
+// All returns an iterator over the entries of m in increasing key
+// order; see the collections package doc for the iterator shape. m must
+// not be modified during the iteration.
+func (m *Map[K, V]) All() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the entries of m in decreasing key
+// order.
+func (m *Map[K, V]) Backward() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.descend(m.root, yield)
+	}
+}
+
+// Range returns an iterator over the entries with lo <= key < hi in
+// increasing key order, visiting only the parts of the tree in range.
+func (m *Map[K, V]) Range(lo, hi K) func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, &lo, &hi, yield)
+	}
+}
+
+// Keys returns the keys of m in increasing order.
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.All()(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// ascend yields the entries of the subtree n within [lo, hi) in order,
+// where a nil bound is unbounded, and reports whether to go on.
+func (m *Map[K, V]) ascend(n *node[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := lo == nil || m.cmp(n.key, *lo) >= 0
+	belowHi := hi == nil || m.cmp(n.key, *hi) < 0
+
+	if aboveLo && !m.ascend(n.left, lo, hi, yield) {
+		return false
+	}
+	if aboveLo && belowHi && !yield(n.key, n.val) {
+		return false
+	}
+	if belowHi {
+		return m.ascend(n.right, lo, hi, yield)
+	}
+	return true
+}
+
+func (m *Map[K, V]) descend(n *node[K, V], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return m.descend(n.right, yield) && yield(n.key, n.val) && m.descend(n.left, yield)
+}
+
+func entry[K, V any](n *node[K, V]) (K, V, bool) {
+	return n.key, n.val, true
+}
+
+func notFound[K, V any]() (K, V, bool) {
+	var k K
+	var v V
+	return k, v, false
+}
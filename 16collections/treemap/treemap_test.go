@@ -0,0 +1,225 @@
+package treemap
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// check verifies the red-black and size invariants of m.
+func check[K, V any](t *testing.T, m *Map[K, V]) {
+	t.Helper()
+	if isRed(m.root) {
+		t.Fatal("root is red")
+	}
+
+	var walk func(n *node[K, V], lo, hi *K) (black int)
+	walk = func(n *node[K, V], lo, hi *K) int {
+		if n == nil {
+			return 1
+		}
+		if lo != nil && m.cmp(n.key, *lo) <= 0 || hi != nil && m.cmp(n.key, *hi) >= 0 {
+			t.Fatalf("key %v out of order", n.key)
+		}
+		if isRed(n.right) {
+			t.Fatalf("right-leaning red link at %v", n.key)
+		}
+		if isRed(n) && isRed(n.left) {
+			t.Fatalf("two red links in a row at %v", n.key)
+		}
+		if n.size != 1+size(n.left)+size(n.right) {
+			t.Fatalf("size %d at %v is wrong", n.size, n.key)
+		}
+
+		bl, br := walk(n.left, lo, &n.key), walk(n.right, &n.key, hi)
+		if bl != br {
+			t.Fatalf("unbalanced at %v: %d vs %d black links", n.key, bl, br)
+		}
+		if !isRed(n) {
+			bl++
+		}
+		return bl
+	}
+	walk(m.root, nil, nil)
+}
+
+func collect[K, V any](seq func(yield func(K, V) bool)) []K {
+	var keys []K
+	seq(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// TestModel performs random puts and deletes on a Map and on a sorted
+// slice of keys, where the value of key k is always -k, and compares
+// the two after each step.
+func TestModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	var model []int
+
+	for step := 0; step < 3000; step++ {
+		k := rng.Intn(200)
+		i, found := slices.BinarySearch(model, k)
+		switch {
+		case rng.Intn(3) > 0:
+			m.Put(k, -k)
+			if !found {
+				model = slices.Insert(model, i, k)
+			}
+		case m.Delete(k) != found:
+			t.Fatalf("Delete(%d) = %v, want %v", k, !found, found)
+		case found:
+			model = slices.Delete(model, i, i+1)
+		}
+
+		check(t, m)
+		if !slices.Equal(m.Keys(), model) || m.Len() != len(model) {
+			t.Fatalf("step %d: keys = %v, want %v", step, m.Keys(), model)
+		}
+		checkQueries(t, m, model, rng.Intn(210)-5, rng.Intn(30))
+	}
+}
+
+// checkQueries compares the lookups around q, and the range scan of
+// width w from q, with the answers read off the sorted model.
+func checkQueries(t *testing.T, m *Map[int, int], model []int, q, w int) {
+	t.Helper()
+
+	// r is the rank of q: model[r] is its ceiling, and its floor is
+	// model[r] on a hit or else model[r-1]
+	r, hit := slices.BinarySearch(model, q)
+	fi := r - 1
+	if hit {
+		fi = r
+	}
+
+	if v, ok := m.Get(q); ok != hit || ok && v != -q {
+		t.Fatalf("Get(%d) = %d, %v", q, v, ok)
+	}
+	if got := m.Rank(q); got != r {
+		t.Fatalf("Rank(%d) = %d, want %d", q, got, r)
+	}
+	if k, v, ok := m.Select(r); ok != (r < len(model)) || ok && (k != model[r] || v != -k) {
+		t.Fatalf("Select(%d) = %d, %d, %v", r, k, v, ok)
+	}
+	if k, _, ok := m.Ceiling(q); ok != (r < len(model)) || ok && k != model[r] {
+		t.Fatalf("Ceiling(%d) = %d, %v", q, k, ok)
+	}
+	if k, _, ok := m.Floor(q); ok != (fi >= 0) || ok && k != model[fi] {
+		t.Fatalf("Floor(%d) = %d, %v", q, k, ok)
+	}
+
+	end, _ := slices.BinarySearch(model, q+w)
+	if got := collect(m.Range(q, q+w)); !slices.Equal(got, model[r:end]) {
+		t.Fatalf("Range(%d, %d) = %v, want %v", q, q+w, got, model[r:end])
+	}
+}
+
+func TestMinMaxBackward(t *testing.T) {
+	m := New[string, int]()
+	if _, _, ok := m.Min(); ok {
+		t.Fatal("Min of empty map reported ok")
+	}
+	if _, _, ok := m.Max(); ok {
+		t.Fatal("Max of empty map reported ok")
+	}
+	if _, _, ok := m.Select(0); ok {
+		t.Fatal("Select(0) of empty map reported ok")
+	}
+	if m.Delete("x") || m.Len() != 0 {
+		t.Fatal("Delete on an empty map")
+	}
+
+	for _, w := range strings.Fields("pear apple fig banana cherry") {
+		m.Put(w, len(w))
+	}
+	if k, v, _ := m.Min(); k != "apple" || v != 5 {
+		t.Fatalf("Min = %s, %d", k, v)
+	}
+	if k, _, _ := m.Max(); k != "pear" {
+		t.Fatalf("Max = %s", k)
+	}
+
+	want := []string{"pear", "fig", "cherry", "banana", "apple"}
+	if got := collect(m.Backward()); !slices.Equal(got, want) {
+		t.Fatalf("Backward = %v", got)
+	}
+}
+
+func TestEarlyStop(t *testing.T) {
+	m := New[int, string]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, "")
+	}
+	for name, seq := range map[string]func(func(int, string) bool){
+		"All": m.All(), "Backward": m.Backward(), "Range": m.Range(10, 90),
+	} {
+		calls := 0
+		seq(func(int, string) bool {
+			calls++
+			return calls < 3
+		})
+		if calls != 3 {
+			t.Fatalf("%s kept going after yield returned false: %d calls", name, calls)
+		}
+	}
+}
+
+func TestCustomComparator(t *testing.T) {
+	// case-insensitive keys, longest first
+	m := NewFunc[string, int](func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	for _, w := range []string{"Go", "gopher", "go", "Map", "tree"} {
+		m.Update(w, func(n int, _ bool) int { return n + 1 })
+	}
+
+	if n, _ := m.Get("GO"); n != 2 {
+		t.Fatalf(`Get("GO") = %d, want 2`, n)
+	}
+	if got := m.Keys(); !slices.Equal(got, []string{"gopher", "tree", "Map", "Go"}) {
+		t.Fatalf("Keys = %v", got)
+	}
+	check(t, m)
+}
+
+func TestUpdate(t *testing.T) {
+	m := New[string, []int]()
+	for i, w := range strings.Fields("a b a c a") {
+		m.Update(w, func(old []int, found bool) []int {
+			if found != (old != nil) {
+				t.Fatalf("Update(%q) found = %v with old %v", w, found, old)
+			}
+			return append(old, i)
+		})
+	}
+	if got, _ := m.Get("a"); !slices.Equal(got, []int{0, 2, 4}) {
+		t.Fatalf(`Get("a") = %v`, got)
+	}
+}
+
+func BenchmarkPut(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Put(rng.Int(), i)
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	m := New[int, int]()
+	for i := 0; i < 1<<16; i++ {
+		m.Put(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i & (1<<16 - 1))
+	}
+}